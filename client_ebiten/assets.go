@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const embeddedAssetDir = "assets"
+
+// An assetFS looks up files in each of its layers in order,
+// so a user given directory can override single embedded files.
+// The last layer is always the embedded assets.
+type assetFS []fs.FS
+
+func newAssetFS(
+	userDir string,
+) (assetFS, error) {
+	var ret assetFS
+
+	if userDir != "" {
+		info, err := os.Stat(userDir)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("\"%v\" is not a directory", userDir)
+		}
+		ret = append(ret, os.DirFS(userDir))
+	}
+
+	embedded, err := fs.Sub(pngs, embeddedAssetDir)
+	if err != nil {
+		return nil, err
+	}
+	ret = append(ret, embedded)
+
+	return ret, nil
+}
+
+func (a assetFS) Open(
+	name string,
+) (fs.File, error) {
+	for i := 0; i < len(a) - 1; i++ {
+		f, err := a[i].Open(name)
+		if !errors.Is(err, fs.ErrNotExist) {
+			return f, err
+		}
+	}
+
+	return a[len(a) - 1].Open(name)
+}
+
+// Opens the image of the first layer that has a fitting one.
+// Images that can't be decoded or are not w x h are skipped with a warning,
+// and only if no layer has a usable image, an error is returned.
+func (a assetFS) Image(
+	name string,
+	w, h int,
+) (*ebiten.Image, error) {
+	var lastErr error
+
+	for i := 0; i < len(a); i++ {
+		img, _, err := ebitenutil.NewImageFromFileSystem(a[i], name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err == nil &&
+		   (img.Bounds().Dx() != w || img.Bounds().Dy() != h) {
+			err = fmt.Errorf("asset \"%v\" is %vx%v but must be %vx%v",
+			                 name,
+			                 img.Bounds().Dx(), img.Bounds().Dy(),
+			                 w, h)
+		} else if err != nil {
+			err = fmt.Errorf("asset \"%v\": %w", name, err)
+		}
+
+		if err == nil {
+			return img, nil
+		}
+
+		lastErr = err
+		if i < len(a) - 1 {
+			fmt.Fprintf(os.Stderr, "%v, falling back\n", err)
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("asset \"%v\" not found", name)
+	}
+
+	return nil, lastErr
+}
+
+// Loads the font of the first layer that has a usable one,
+// along with the metrics file of that same layer.
+// Broken fonts are skipped with a warning, like images are.
+func (a assetFS) Font(
+	name string,
+) (*ui.Font, error) {
+	var lastErr error
+
+	for i := 0; i < len(a); i++ {
+		f, err := ui.LoadFont(a[i], name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err == nil {
+			return f, nil
+		}
+
+		lastErr = fmt.Errorf("font \"%v\": %w", name, err)
+		if i < len(a) - 1 {
+			fmt.Fprintf(os.Stderr, "%v, falling back\n", lastErr)
+		}
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("font \"%v\" not found", name)
+	}
+
+	return nil, lastErr
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/SchokiCoder/hawps/core"
+)
+
+// What happens at one edge of the world.
+// T is only used by the fixed temperature wall.
+type boundary struct {
+	Mode core.Boundary
+	T    float64
+}
+
+// Parses "wall", "void", "wrap" or "fixed:T",
+// where T is a temperature as parseTemperature takes it.
+func parseBoundary(
+	s string,
+) (boundary, error) {
+	switch s {
+	case "wall":
+		return boundary{Mode: core.Wall}, nil
+
+	case "void":
+		return boundary{Mode: core.Void}, nil
+
+	case "wrap":
+		return boundary{Mode: core.Wrap}, nil
+	}
+
+	t, found := strings.CutPrefix(s, "fixed:")
+	if !found {
+		return boundary{}, fmt.Errorf("unknown boundary \"%v\"", s)
+	}
+
+	kelvin, err := parseTemperature(t)
+	if err != nil {
+		return boundary{}, err
+	}
+
+	return boundary{Mode: core.FixedT, T: kelvin}, nil
+}
+
+// Hands the boundaries over to the world,
+// which needs to happen again whenever a new world is made.
+func (g *physGame) ApplyBoundaries(
+) {
+	for e := core.Edge(0); e < core.EdgeCount; e++ {
+		g.World.SetBoundary(e, g.Boundaries[e].Mode, g.Boundaries[e].T)
+	}
+}
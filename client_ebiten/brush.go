@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Which dots the Brush may paint over.
+type brushMode int
+
+const (
+	brushAll brushMode = iota
+	brushEmpty
+	brushReplace
+
+	brushModeCount
+)
+
+const (
+	brushSprayStep = 10
+	brushSprayMin  = brushSprayStep
+)
+
+// Visits the cells of the footprint of the Brush, centered at x, y.
+// These may lie outside of the world.
+func (g physGame) brushCells(
+	x, y  int,
+	visit func(x, y int),
+) {
+	var r = g.BrushRadius
+
+	if g.BrushRound {
+		circleCells(x, y, r, true, visit)
+	} else {
+		rectCells(x - r, y - r, x + r, y + r, true, visit)
+	}
+}
+
+// Paints the footprint of the Brush at x, y,
+// skipping what the mode forbids and, when spraying, some cells at random.
+func (g *physGame) PaintBrush(
+	x, y int,
+) {
+	var m = g.CurMat()
+
+	g.brushCells(x, y, func(cx, cy int) {
+		if !g.InWorldBounds(cx, cy) {
+			return
+		}
+
+		switch g.BrushMode {
+		case brushEmpty:
+			if mat.None != g.World.Dot[cx][cy] {
+				return
+			}
+
+		case brushReplace:
+			if g.BrushTarget != g.World.Dot[cx][cy] {
+				return
+			}
+		}
+
+		if rand.Intn(100) >= g.BrushSpray {
+			return
+		}
+
+		g.History.Touch(&g.World, cx, cy)
+		g.World.UseBrush(m, g.Temperature, cx, cy, 0)
+	})
+}
+
+// Handles a just pressed key, while the Brush tool is used.
+// N cycles the modes, G makes the mat under the cursor the one to replace,
+// O switches between a round and square footprint,
+// and Comma and Period change the spray density.
+func (g *physGame) HandleBrushKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyN:
+		g.BrushMode = (g.BrushMode + 1) % brushModeCount
+
+	case ebiten.KeyG:
+		x, y := g.HoverPos()
+		if g.InWorldBounds(x, y) {
+			g.BrushTarget = g.World.Dot[x][y]
+			g.BrushMode = brushReplace
+		}
+
+	case ebiten.KeyO:
+		g.BrushRound = !g.BrushRound
+
+	case ebiten.KeyComma:
+		g.BrushSpray = max(g.BrushSpray - brushSprayStep, brushSprayMin)
+
+	case ebiten.KeyPeriod:
+		g.BrushSpray = min(g.BrushSpray + brushSprayStep, 100)
+	}
+}
+
+// Shows the mode of the Brush, below the temperature of new dots.
+func (g physGame) drawBrushInfo(
+	screen *ebiten.Image,
+) {
+	var (
+		mode  string
+		shape = "square"
+		text  string
+	)
+
+	switch g.BrushMode {
+	case brushAll:
+		mode = "paints over all"
+
+	case brushEmpty:
+		mode = "paints empty only"
+
+	case brushReplace:
+		mode = "replaces " + mat.Name(g.BrushTarget)
+		if mat.None == g.BrushTarget {
+			mode = "replaces empty"
+		}
+	}
+
+	if g.BrushRound {
+		shape = "round"
+	}
+
+	text = fmt.Sprintf("%v, %v, %v%%", mode, shape, g.BrushSpray)
+
+	ui.DrawText(screen,
+	            g.WorldX + 2,
+	            g.WorldY + 4 + ui.DrawnTextH(text),
+	            text,
+	            uiFontSpacing)
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+	"github.com/SchokiCoder/hawps/extra"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	drainR = 0
+	drainG = 200
+	drainB = 200
+	drainA = 160
+
+	heatSourceR = 255
+	heatSourceG = 80
+	heatSourceB = 0
+	heatSourceA = 160
+
+	heatSinkR = 80
+	heatSinkG = 160
+	heatSinkB = 255
+	heatSinkA = 160
+
+	stdHeatDelta   = heaterDelta
+	stdHeatSinkT   = -50 + celsiusToKelvin
+	stdHeatSourceT = 800 + celsiusToKelvin
+	heatDeltaStep  = 0.5
+	heatDeltaFast  = 5
+)
+
+// The emitter a tool places, if any.
+func toolEmitter(
+	t extra.Tool,
+) core.Emitter {
+	switch t {
+	case extra.Drain:
+		return core.Drain
+
+	case extra.HeatSource:
+		return core.HeatSource
+
+	case extra.HeatSink:
+		return core.HeatSink
+	}
+
+	return core.EmitterNone
+}
+
+// Places the emitter of the current tool at x, y.
+func (g *physGame) PlaceEmitter(
+	x, y int,
+) {
+	var (
+		e = toolEmitter(extra.Tool(g.Toolbox.Cursor))
+		m = mat.None
+		t = g.HeatDelta
+	)
+
+	switch e {
+	case core.Drain:
+		m = g.CurMat()
+
+	case core.HeatSource:
+		if g.HeatFixed {
+			t = g.HeatSourceT
+		}
+
+	case core.HeatSink:
+		if g.HeatFixed {
+			t = g.HeatSinkT
+		}
+	}
+
+	g.World.SetEmitter(e, m, t, g.HeatFixed, x, y)
+}
+
+// Handles a just pressed key, while a heat source or sink tool is used.
+// [ and ] change the temperature, or the delta if it isn't fixed,
+// and M switches between both.
+func (g *physGame) HandleHeatKey(
+	key ebiten.Key,
+) {
+	var (
+		delta  float64
+		target *float64
+		shift  = ebiten.IsKeyPressed(ebiten.KeyShift)
+	)
+
+	switch key {
+	case ebiten.KeyM:
+		g.HeatFixed = !g.HeatFixed
+		return
+
+	case ebiten.KeyBracketLeft:
+		delta = -1
+
+	case ebiten.KeyBracketRight:
+		delta = 1
+
+	default:
+		return
+	}
+
+	if !g.HeatFixed {
+		if shift {
+			delta *= heatDeltaFast
+		} else {
+			delta *= heatDeltaStep
+		}
+		g.HeatDelta = max(g.HeatDelta + delta, 0)
+		return
+	}
+
+	if shift {
+		delta *= spawnerTFastStep
+	} else {
+		delta *= spawnerTStep
+	}
+
+	if extra.HeatSource == extra.Tool(g.Toolbox.Cursor) {
+		target = &g.HeatSourceT
+	} else {
+		target = &g.HeatSinkT
+	}
+	*target = max(*target + delta, 0)
+}
+
+func emitterColor(
+	e core.Emitter,
+) color.Color {
+	switch e {
+	case core.Drain:
+		return color.RGBA{drainR, drainG, drainB, drainA}
+
+	case core.HeatSource:
+		return color.RGBA{heatSourceR, heatSourceG, heatSourceB, heatSourceA}
+
+	case core.HeatSink:
+		return color.RGBA{heatSinkR, heatSinkG, heatSinkB, heatSinkA}
+	}
+
+	return color.RGBA{}
+}
+
+// Shows what new emitters are placed with, in the top left of the world.
+func (g physGame) drawEmitterInfo(
+	screen *ebiten.Image,
+) {
+	var text string
+
+	switch extra.Tool(g.Toolbox.Cursor) {
+	case extra.Drain:
+		text = "drains any mat"
+		if cur := g.CurMat(); mat.None != cur {
+			text = "drains " + mat.Name(cur)
+		}
+
+	case extra.HeatSource:
+		if g.HeatFixed {
+			text = "heats up to " + g.FormatT(g.HeatSourceT)
+		} else {
+			text = fmt.Sprintf("heats by %.1f K per tick", g.HeatDelta)
+		}
+
+	case extra.HeatSink:
+		if g.HeatFixed {
+			text = "cools down to " + g.FormatT(g.HeatSinkT)
+		} else {
+			text = fmt.Sprintf("cools by %.1f K per tick", g.HeatDelta)
+		}
+
+	default:
+		return
+	}
+
+	ui.DrawText(screen, g.WorldX + 2, g.WorldY + 2, text, uiFontSpacing)
+}
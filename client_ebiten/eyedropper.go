@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/extra"
+)
+
+// Selects the mat in the Matbox, if it is visible there.
+func (g *physGame) SelectMat(
+	m mat.Mat,
+) bool {
+	for i := 0; i < len(g.Matbox.VisibleTiles); i++ {
+		if mat.Mat(g.Matbox.VisibleTiles[i]) == m {
+			g.Matbox.Cursor = i
+			return true
+		}
+	}
+
+	return false
+}
+
+// Adopts the mat and temperature of the dot at wX, wY for the given tool.
+// The Spawner adopts those of the spawner at wX, wY instead, if there is one.
+// Only the Spawner and tools that place dots can sample.
+// Since spawners can't spawn static dots,
+// the Spawner keeps its settings when sampling a mat that is static there.
+func (g *physGame) Sample(
+	wX, wY int,
+	tool   extra.Tool,
+) {
+	var (
+		cur = extra.Tool(g.Toolbox.Cursor)
+		m   mat.Mat
+		t   float64
+	)
+
+	if !g.InWorldBounds(wX, wY) {
+		return
+	}
+
+	if extra.Spawner == tool && g.World.Spawner[wX][wY] {
+		m = g.World.SpwnMat[wX][wY]
+		t = g.World.SpwnThermo[wX][wY]
+	} else if extra.Spawner == tool || isBrushTool(tool) {
+		m = g.World.Dot[wX][wY]
+		t = g.World.Thermo[wX][wY]
+		if mat.None == m {
+			return
+		}
+	} else {
+		return
+	}
+
+	if extra.Spawner == tool && mat.Static == mat.ThermoToState(m, t) {
+		fmt.Fprintf(os.Stderr, "Could not sample %v, which is static at %v\n",
+		            mat.Name(m), g.FormatT(t))
+		return
+	}
+
+	// The Matbox of the tool is where the index of the mat is known,
+	// so it is shown for a moment, without switching tools for good.
+	g.SaveMatboxCursor(cur)
+	g.Toolbox.Cursor = int(tool)
+	g.UpdateMatbox()
+
+	if extra.Spawner == tool {
+		g.SpawnerT = t
+		g.RefreshMatbox()
+	} else {
+		g.SetBrushT(t)
+	}
+
+	if g.SelectMat(m) {
+		g.SaveMatboxCursor(tool)
+	} else {
+		fmt.Fprintf(os.Stderr, "Could not sample %v for the %v tool\n",
+		            mat.Name(m), tool)
+	}
+
+	g.Toolbox.Cursor = int(cur)
+	g.UpdateMatbox()
+}
+
+// The Eyedropper samples for the Spawner, when used on a spawner,
+// and otherwise for the Brush.
+func (g *physGame) UseEyedropper(
+	wX, wY int,
+) {
+	if g.World.Spawner[wX][wY] {
+		g.Sample(wX, wY, extra.Spawner)
+	} else {
+		g.Sample(wX, wY, extra.Brush)
+	}
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	gamepadDeadzone     = 0.2
+	// world cells per tick at full stick deflection
+	gamepadCursorSpeed  = 0.5
+	// radius steps per tick at full stick deflection
+	gamepadRadiusSpeed  = 0.15
+	gamepadTriggerPress = 0.5
+)
+
+func gamepadAxis(
+	id   ebiten.GamepadID,
+	axis ebiten.StandardGamepadAxis,
+) float64 {
+	v := ebiten.StandardGamepadAxisValue(id, axis)
+	if v > -gamepadDeadzone && v < gamepadDeadzone {
+		return 0
+	}
+	return v
+}
+
+// Only gamepads with a standard layout are supported.
+// The left stick moves the world cursor and the triggers use the tool there.
+// The shoulder buttons cycle through tools and materials,
+// the right stick changes the radius and Start pauses.
+func (g *physGame) HandleGamepads(
+) {
+	g.Gamepads = ebiten.AppendGamepadIDs(g.Gamepads[:0])
+
+	for i := 0; i < len(g.Gamepads); i++ {
+		if !ebiten.IsStandardGamepadLayoutAvailable(g.Gamepads[i]) {
+			continue
+		}
+		g.handleGamepad(g.Gamepads[i])
+	}
+}
+
+func (g *physGame) handleGamepad(
+	id ebiten.GamepadID,
+) {
+	var (
+		dx, dy float64
+		steps  int
+	)
+
+	justPressed := func(b ebiten.StandardGamepadButton) bool {
+		return inpututil.IsStandardGamepadButtonJustPressed(id, b)
+	}
+
+	if justPressed(ebiten.StandardGamepadButtonCenterRight) {
+		g.Paused = !g.Paused
+	}
+
+	if justPressed(ebiten.StandardGamepadButtonFrontTopLeft) &&
+	   len(g.Toolbox.VisibleTiles) > 0 {
+		g.SelectTool((g.Toolbox.Cursor + 1) %
+		             len(g.Toolbox.VisibleTiles))
+	}
+
+	if justPressed(ebiten.StandardGamepadButtonFrontTopRight) &&
+	   len(g.Matbox.VisibleTiles) > 0 {
+		g.Matbox.Cursor = (g.Matbox.Cursor + 1) %
+		                  len(g.Matbox.VisibleTiles)
+	}
+
+	dx = gamepadAxis(id, ebiten.StandardGamepadAxisLeftStickHorizontal)
+	dy = gamepadAxis(id, ebiten.StandardGamepadAxisLeftStickVertical)
+	if dx != 0 || dy != 0 {
+		g.MoveWCursor(dx * gamepadCursorSpeed, dy * gamepadCursorSpeed)
+	}
+
+	// stick up is negative, but should grow the radius
+	dy = gamepadAxis(id, ebiten.StandardGamepadAxisRightStickVertical)
+	g.GamepadRadiusAcc -= dy * gamepadRadiusSpeed
+	steps = int(g.GamepadRadiusAcc)
+	if steps != 0 {
+		g.GamepadRadiusAcc -= float64(steps)
+		g.ChangeRadius(steps)
+	}
+
+	if ebiten.StandardGamepadButtonValue(id,
+	       ebiten.StandardGamepadButtonFrontBottomLeft) >
+	   gamepadTriggerPress ||
+	   ebiten.StandardGamepadButtonValue(id,
+	       ebiten.StandardGamepadButtonFrontBottomRight) >
+	   gamepadTriggerPress {
+		g.GamepadUsing = true
+		g.WCursorShown = true
+		g.UseTool(int(g.WCursorX), int(g.WCursorY))
+	} else if g.GamepadUsing {
+		g.GamepadUsing = false
+		g.EndStroke()
+	}
+}
+
+// Moves the world cursor by the given amount of cells and shows it.
+// If it wasn't shown, it starts where the pointer last was.
+func (g *physGame) MoveWCursor(
+	dx, dy float64,
+) {
+	if !g.WCursorShown {
+		x, y := g.HoverPos()
+		g.WCursorX = float64(x) + 0.5
+		g.WCursorY = float64(y) + 0.5
+		g.WCursorShown = true
+	}
+
+	g.WCursorX += dx
+	g.WCursorY += dy
+
+	if g.WCursorX < 0 {
+		g.WCursorX = 0
+	} else if g.WCursorX >= float64(g.World.W) {
+		g.WCursorX = float64(g.World.W) - 0.01
+	}
+	if g.WCursorY < 0 {
+		g.WCursorY = 0
+	} else if g.WCursorY >= float64(g.World.H) {
+		g.WCursorY = float64(g.World.H) - 0.01
+	}
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"unsafe"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/core/mat"
+)
+
+const (
+	stdUndoMem = 64 // MiB
+)
+
+// Everything the world knows about a single dot.
+type cellState struct {
+	Dissol       float64
+	Dot          mat.Mat
+	Emitter      core.Emitter
+	EmtFixed     bool
+	EmtMat       mat.Mat
+	EmtThermo    float64
+	Lock         core.Lock
+	Oxid         float64
+	Spawner      bool
+	SpwnBurst    int
+	SpwnChance   int
+	SpwnInterval int
+	SpwnMat      mat.Mat
+	SpwnThermo   float64
+	State        mat.State
+	Thermo       float64
+	Weight       float64
+}
+
+func getCell(
+	w    *core.World,
+	x, y int,
+) cellState {
+	return cellState{
+		Dissol:       w.Dissol[x][y],
+		Dot:          w.Dot[x][y],
+		Emitter:      w.Emitter[x][y],
+		EmtFixed:     w.EmtFixed[x][y],
+		EmtMat:       w.EmtMat[x][y],
+		EmtThermo:    w.EmtThermo[x][y],
+		Lock:         w.Lock[x][y],
+		Oxid:         w.Oxid[x][y],
+		Spawner:      w.Spawner[x][y],
+		SpwnBurst:    w.SpwnBurst[x][y],
+		SpwnChance:   w.SpwnChance[x][y],
+		SpwnInterval: w.SpwnInterval[x][y],
+		SpwnMat:      w.SpwnMat[x][y],
+		SpwnThermo:   w.SpwnThermo[x][y],
+		State:        w.State[x][y],
+		Thermo:       w.Thermo[x][y],
+		Weight:       w.Weight[x][y],
+	}
+}
+
+func (c cellState) Put(
+	w    *core.World,
+	x, y int,
+) {
+	w.Dissol[x][y] = c.Dissol
+	w.Dot[x][y] = c.Dot
+	w.Emitter[x][y] = c.Emitter
+	w.EmtFixed[x][y] = c.EmtFixed
+	w.EmtMat[x][y] = c.EmtMat
+	w.EmtThermo[x][y] = c.EmtThermo
+	w.Lock[x][y] = c.Lock
+	w.Oxid[x][y] = c.Oxid
+	w.Spawner[x][y] = c.Spawner
+	w.SpwnBurst[x][y] = c.SpwnBurst
+	w.SpwnChance[x][y] = c.SpwnChance
+	w.SpwnInterval[x][y] = c.SpwnInterval
+	w.SpwnMat[x][y] = c.SpwnMat
+	w.SpwnThermo[x][y] = c.SpwnThermo
+	w.State[x][y] = c.State
+	w.Thermo[x][y] = c.Thermo
+	w.Weight[x][y] = c.Weight
+}
+
+type cellEdit struct {
+	X, Y int
+	Old  cellState
+	New  cellState
+}
+
+// One stroke, from press to release.
+// Either the edited cells are known,
+// or the whole world is kept, which is swapped with the world on undo and redo.
+type historyEntry struct {
+	Edits    []cellEdit
+	Snapshot []cellState
+}
+
+func (e historyEntry) Size(
+) int {
+	return len(e.Edits) * int(unsafe.Sizeof(cellEdit{})) +
+	       len(e.Snapshot) * int(unsafe.Sizeof(cellState{}))
+}
+
+// Undo and redo of tool strokes.
+// Entries[:Pos] can be undone, Entries[Pos:] can be redone.
+// If Snapshots is set, undoing a stroke also reverts everything the simulation
+// did since then.
+// Once the entries use more than Budget bytes, the oldest are forgotten.
+type history struct {
+	Budget    int
+	Entries   []historyEntry
+	Pos       int
+	Recording bool
+	Snapshots bool
+	Cur       historyEntry
+	// whether the current stroke touched any cell, with Snapshots
+	Edited    bool
+	// index into Cur.Edits, by cell index
+	Touched   map[int]int
+	Used      int
+}
+
+func takeSnapshot(
+	w *core.World,
+) []cellState {
+	var ret = make([]cellState, w.W * w.H)
+
+	for x := 0; x < w.W; x++ {
+		for y := 0; y < w.H; y++ {
+			ret[x * w.H + y] = getCell(w, x, y)
+		}
+	}
+
+	return ret
+}
+
+func snapshotMatches(
+	w    *core.World,
+	snap []cellState,
+) bool {
+	for x := 0; x < w.W; x++ {
+		for y := 0; y < w.H; y++ {
+			if snap[x * w.H + y] != getCell(w, x, y) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Exchanges the world with the snapshot.
+func swapSnapshot(
+	w    *core.World,
+	snap []cellState,
+) {
+	for x := 0; x < w.W; x++ {
+		for y := 0; y < w.H; y++ {
+			c := getCell(w, x, y)
+			snap[x * w.H + y].Put(w, x, y)
+			snap[x * w.H + y] = c
+		}
+	}
+}
+
+// Starts recording a stroke.
+func (h *history) Begin(
+	w *core.World,
+) {
+	h.Recording = true
+	h.Cur = historyEntry{}
+	h.Edited = false
+
+	if h.Snapshots {
+		h.Cur.Snapshot = takeSnapshot(w)
+	} else {
+		h.Touched = make(map[int]int)
+	}
+}
+
+// Remembers a cell, before the current stroke changes it.
+// Cells outside of the world are ignored.
+func (h *history) Touch(
+	w    *core.World,
+	x, y int,
+) {
+	if !h.Recording || x < 0 || x >= w.W || y < 0 || y >= w.H {
+		return
+	}
+
+	if h.Snapshots {
+		h.Edited = true
+		return
+	}
+
+	if _, ok := h.Touched[x * w.H + y]; ok {
+		return
+	}
+
+	h.Touched[x * w.H + y] = len(h.Cur.Edits)
+	h.Cur.Edits = append(h.Cur.Edits, cellEdit{
+		X:   x,
+		Y:   y,
+		Old: getCell(w, x, y),
+	})
+}
+
+func (h *history) TouchArea(
+	w      *core.World,
+	x, y   int,
+	radius int,
+) {
+	for cx := x - radius; cx <= x + radius; cx++ {
+		for cy := y - radius; cy <= y + radius; cy++ {
+			h.Touch(w, cx, cy)
+		}
+	}
+}
+
+// Finishes recording the current stroke.
+// Strokes that changed nothing are not kept.
+// With Snapshots, that is strokes that touched no cell,
+// or after which the world is still the same.
+func (h *history) End(
+	w *core.World,
+) {
+	var edits []cellEdit
+
+	if !h.Recording {
+		return
+	}
+	h.Recording = false
+	h.Touched = nil
+
+	if !h.Snapshots {
+		for i := 0; i < len(h.Cur.Edits); i++ {
+			e := h.Cur.Edits[i]
+			e.New = getCell(w, e.X, e.Y)
+			if e.New != e.Old {
+				edits = append(edits, e)
+			}
+		}
+		if len(edits) == 0 {
+			return
+		}
+		h.Cur.Edits = edits
+	} else if !h.Edited || snapshotMatches(w, h.Cur.Snapshot) {
+		h.Cur = historyEntry{}
+		return
+	}
+
+	for i := h.Pos; i < len(h.Entries); i++ {
+		h.Used -= h.Entries[i].Size()
+	}
+	h.Entries = append(h.Entries[:h.Pos], h.Cur)
+	h.Pos++
+	h.Used += h.Cur.Size()
+	h.Cur = historyEntry{}
+
+	for h.Used > h.Budget && len(h.Entries) > 0 {
+		h.Used -= h.Entries[0].Size()
+		h.Entries[0] = historyEntry{}
+		h.Entries = h.Entries[1:]
+		h.Pos--
+	}
+}
+
+func (h *history) Undo(
+	w *core.World,
+) {
+	if h.Recording || h.Pos <= 0 {
+		return
+	}
+
+	h.Pos--
+	e := h.Entries[h.Pos]
+
+	if e.Snapshot != nil {
+		swapSnapshot(w, e.Snapshot)
+		return
+	}
+
+	for i := len(e.Edits) - 1; i >= 0; i-- {
+		e.Edits[i].Old.Put(w, e.Edits[i].X, e.Edits[i].Y)
+	}
+}
+
+func (h *history) Redo(
+	w *core.World,
+) {
+	if h.Recording || h.Pos >= len(h.Entries) {
+		return
+	}
+
+	e := h.Entries[h.Pos]
+	h.Pos++
+
+	if e.Snapshot != nil {
+		swapSnapshot(w, e.Snapshot)
+		return
+	}
+
+	for i := 0; i < len(e.Edits); i++ {
+		e.Edits[i].New.Put(w, e.Edits[i].X, e.Edits[i].Y)
+	}
+}
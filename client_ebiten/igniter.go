@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+)
+
+const (
+	sparkR = 255
+	sparkG = 220
+	sparkB = 80
+	// in ticks
+	sparkLife = 12
+	// percentage of ignited dots that throw a spark
+	sparkChance = 25
+)
+
+// A short lived dot of light, that rises from ignited dots.
+// It is only drawn and doesn't exist in the world.
+type spark struct {
+	X, Y int
+	Life int
+}
+
+// Throws sparks from the dots in the area, that the igniter can ignite.
+func (g *physGame) AddSparks(
+	x, y   int,
+	radius int,
+) {
+	for sx := x - radius; sx <= x + radius; sx++ {
+		for sy := y - radius; sy <= y + radius; sy++ {
+			if !g.InWorldBounds(sx, sy) ||
+			   mat.IgnP(g.World.Dot[sx][sy]) == 0 ||
+			   rand.Intn(100) >= sparkChance {
+				continue
+			}
+
+			g.Sparks = append(g.Sparks, spark{
+				X:    sx,
+				Y:    sy,
+				Life: sparkLife - rand.Intn(sparkLife / 2),
+			})
+		}
+	}
+}
+
+// Lets sparks rise and flicker sideways, until they burn out.
+func (g *physGame) UpdateSparks(
+) {
+	var alive = g.Sparks[:0]
+
+	for i := 0; i < len(g.Sparks); i++ {
+		s := g.Sparks[i]
+
+		s.Life--
+		if s.Life <= 0 {
+			continue
+		}
+		s.X += rand.Intn(3) - 1
+		s.Y--
+
+		alive = append(alive, s)
+	}
+
+	g.Sparks = alive
+}
+
+func (g physGame) drawSparks(
+) {
+	for i := 0; i < len(g.Sparks); i++ {
+		s := g.Sparks[i]
+		a := uint8(255 * s.Life / sparkLife)
+
+		g.ToolImg.Set(s.X, s.Y, color.NRGBA{sparkR, sparkG, sparkB, a})
+	}
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+const (
+	// ticks until a held key starts repeating, and ticks between repeats
+	keyRepeatDelay    = 30
+	keyRepeatInterval = 4
+
+	wCursorFastStep = 8
+)
+
+func keyRepeated(
+	key ebiten.Key,
+) bool {
+	d := inpututil.KeyPressDuration(key)
+
+	if 1 == d {
+		return true
+	}
+	if d > keyRepeatDelay &&
+	   (d - keyRepeatDelay) % keyRepeatInterval == 0 {
+		return true
+	}
+	return false
+}
+
+// Moves the world cursor with H, J, K and L, like the terminal client does.
+// Shift moves it faster and Control moves it all the way to the edge.
+// E uses the current tool at the world cursor,
+// while C and V decrease and increase the tool radius.
+func (g *physGame) HandleWCursorKeys(
+) {
+	var (
+		dx, dy float64
+		step   float64 = 1
+	)
+
+	if ebiten.IsKeyPressed(ebiten.KeyShift) {
+		step = wCursorFastStep
+	}
+	if ebiten.IsKeyPressed(ebiten.KeyControl) {
+		step = float64(max(g.World.W, g.World.H))
+	}
+
+	if keyRepeated(ebiten.KeyH) {
+		dx -= step
+	}
+	if keyRepeated(ebiten.KeyL) {
+		dx += step
+	}
+	if keyRepeated(ebiten.KeyK) {
+		dy -= step
+	}
+	if keyRepeated(ebiten.KeyJ) {
+		dy += step
+	}
+
+	if dx != 0 || dy != 0 {
+		g.MoveWCursor(dx, dy)
+	}
+
+	if keyRepeated(ebiten.KeyC) {
+		g.ChangeRadius(-1)
+	}
+	if keyRepeated(ebiten.KeyV) {
+		g.ChangeRadius(1)
+	}
+
+	if ebiten.IsKeyPressed(ebiten.KeyE) {
+		g.MoveWCursor(0, 0)
+		g.UseTool(int(g.WCursorX), int(g.WCursorY))
+	} else if inpututil.IsKeyJustReleased(ebiten.KeyE) {
+		g.EndStroke()
+	}
+}
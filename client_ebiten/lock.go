@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"image/color"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	lockToolsR = 255
+	lockToolsG = 200
+	lockToolsB = 0
+	lockToolsA = 200
+
+	lockFrozenR = 120
+	lockFrozenG = 220
+	lockFrozenB = 255
+	lockFrozenA = 200
+
+	stdLockRadius = stdBrushRadius
+)
+
+// Handles a just pressed key, while the Lock tool is used.
+// M cycles between locking against tools, freezing and unlocking.
+func (g *physGame) HandleLockKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyM:
+		switch g.LockMode {
+		case core.LockTools:
+			g.LockMode = core.LockFrozen
+
+		case core.LockFrozen:
+			g.LockMode = core.LockNone
+
+		default:
+			g.LockMode = core.LockTools
+		}
+	}
+}
+
+// Locked areas are only outlined, so what is inside stays visible.
+// Whether a dot is at the edge of its area is found out by its neighbours.
+func (g physGame) isLockOutline(
+	x, y int,
+) bool {
+	var (
+		dirs = [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+		l    = g.World.Lock[x][y]
+	)
+
+	for i := 0; i < len(dirs); i++ {
+		nx := x + dirs[i][0]
+		ny := y + dirs[i][1]
+
+		if !g.InWorldBounds(nx, ny) || g.World.Lock[nx][ny] != l {
+			return true
+		}
+	}
+
+	return false
+}
+
+func lockColor(
+	l core.Lock,
+) color.Color {
+	switch l {
+	case core.LockTools:
+		return color.RGBA{lockToolsR, lockToolsG, lockToolsB, lockToolsA}
+
+	case core.LockFrozen:
+		return color.RGBA{lockFrozenR, lockFrozenG, lockFrozenB, lockFrozenA}
+	}
+
+	return color.RGBA{}
+}
+
+// Shows what the Lock tool does, in the top left of the world.
+func (g physGame) drawLockInfo(
+	screen *ebiten.Image,
+) {
+	var text string
+
+	switch g.LockMode {
+	case core.LockTools:
+		text = "locks against tools"
+
+	case core.LockFrozen:
+		text = "freezes, also against the simulation"
+
+	default:
+		text = "unlocks"
+	}
+
+	ui.DrawText(screen, g.WorldX + 2, g.WorldY + 2, text, uiFontSpacing)
+}
@@ -9,6 +9,7 @@ import (
 	"image"
 	"image/color"
 	_ "image/png"
+	"path/filepath"
 	"strconv"
 	"os"
 
@@ -18,7 +19,6 @@ import (
 	"github.com/SchokiCoder/hawps/client_ebiten/ui"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 )
@@ -49,6 +49,7 @@ const (
 
 	stdBrushRadius  = 2
 	stdEraserRadius = 5
+	stdHammerRadius = stdEraserRadius
 	stdThermoRadius = stdBrushRadius
 	maxRadius = 16
 	thermalVisionMinT  = -75 + celsiusToKelvin
@@ -69,8 +70,10 @@ const (
 	uiMatBgG       = 110
 	uiMatBgB       = 130
 	uiMatBgA       = 255
+	uiFontFile     = "font.png"
 	uiTileSetW     = 3
 	uiSymbolFontSpacing = 1
+	uiFontSpacing  = 1
 
 	spawnerR       = 255
 	spawnerG       = 0
@@ -88,6 +91,11 @@ const (
 	toolHoverB     = 175
 	toolHoverA     = spawnerA
 
+	wCursorR       = 255
+	wCursorG       = 255
+	wCursorB       = 255
+	wCursorA       = 200
+
 	wBgR           = 0
 	wBgG           = 0
 	wBgB           = 0
@@ -97,26 +105,103 @@ const (
 )
 
 type physGame struct {
+	Assets       assetFS
 	BgColor      color.RGBA
+	Boundaries   [core.EdgeCount]boundary
 	BrushMat     int
+	BrushMode    brushMode
 	BrushRadius  int
+	BrushRound   bool
+	// percentage of cells the Brush paints, less than 100 sprays
+	BrushSpray   int
+	// the mat brushReplace paints over
+	BrushTarget  mat.Mat
+	// show temperatures in Celsius rather than Kelvin
+	Celsius      bool
+	Clipboard    clipboard
+	// world command waiting for confirmation, if any
+	Confirm      *worldCmd
+	// last pointer position of any input method, in screen coordinates
+	CursorX      int
+	CursorY      int
+	DrainMat     int
 	EraserRadius int
 	ThermoRadius int
 	FrameW       int
 	FrameH       int
+	Gamepads     []ebiten.GamepadID
+	GamepadRadiusAcc float64
+	GamepadUsing bool
 	GlowImg      *ebiten.Image
+	GridSize     int
+	HammerRadius int
+	LockMode     core.Lock
+	LockRadius   int
+	Mirror       mirrorMode
+	// twice the position of the mirror axes, see mirrorPos
+	MirrorX2     int
+	MirrorY2     int
+	HeatDelta    float64
+	HeaterDelta  float64
+	HeatFixed    bool
+	HeatSinkT    float64
+	HeatSourceT  float64
+	History      history
 	Matbox       ui.TileSet
+	// show the grid, rulers and coordinates
+	Overlay      bool
+	MatImgs      []*ebiten.Image
+	// the temperature MatImgs show the states of the mats at
+	MatImgsT     float64
+	MouseX       int
+	MouseY       int
 	Paused       bool
+	Pasting      bool
+	PrefabCur    int
+	PrefabDir    string
+	PrefabImgs   []*ebiten.Image
+	Prefabs      []prefab
+	PresetPath   string
+	Presets      [presetCount]*preset
+	RecipeCur    int
+	RecipeImgs   []*ebiten.Image
+	RecipePath   string
+	Recipes      []recipe
+	Selection    selection
+	Sparks       []spark
+	ShapeFilled  bool
+	// temperature of new dots
 	Temperature  float64
+	Text         string
+	// what is typed, while typing, and what it is for
+	TextDone     func(string)
+	TextInput    []rune
+	TextPrompt   string
+	TextScale    int
+	TextStamp    textStamp
+	ThermoSet    bool
+	ThermoSetT   float64
 	ThVision     bool
 	Tickrate     int
 	Toolbox      ui.TileSet
 	SimSubsample int
+	SpawnerBurst int
+	SpawnerChance int
+	SpawnerInterval int
 	SpawnerMat   int
+	// temperature new spawners spawn at
+	SpawnerT     float64
+	Stroke       strokeState
 	// ticks since last simulation
 	TsSinceSim   int
 	ToolImg      *ebiten.Image
+	Touch        touchState
+	Touches      []touch
 	World        core.World
+	// world cursor, used by input methods that can't point at the screen
+	WCursorX     float64
+	WCursorY     float64
+	WCursorShown bool
 	WorldImg     *ebiten.Image
 	WorldScale   int
 	WorldX       int
@@ -128,9 +213,29 @@ func newPhysGame(
 	var ret = physGame{
 		BgColor:      color.RGBA{R: wBgR, G: wBgG, B: wBgB, A: 255},
 		BrushRadius:  stdBrushRadius,
+		BrushSpray:   100,
+		Celsius:      true,
 		EraserRadius: stdEraserRadius,
+		GridSize:     stdGridSize,
+		HammerRadius: stdHammerRadius,
+		LockMode:     core.LockTools,
+		LockRadius:   stdLockRadius,
+		History:      history{Budget: stdUndoMem * 1024 * 1024},
+		SpawnerChance: 100,
+		SpawnerInterval: 1,
+		PrefabDir:    stdPrefabDir(),
+		PresetPath:   stdPresetPath(),
+		RecipePath:   stdRecipePath(),
 		ThermoRadius: stdThermoRadius,
+		HeatDelta:    stdHeatDelta,
+		HeaterDelta:  heaterDelta,
+		HeatFixed:    true,
+		HeatSinkT:    stdHeatSinkT,
+		HeatSourceT:  stdHeatSourceT,
 		Temperature:  stdTemperature,
+		Text:         stdText,
+		TextScale:    stdTextScale,
+		ThermoSetT:   stdTemperature,
 		Tickrate:     stdTickrate,
 		TsSinceSim:   9001,
 		SimSubsample: stdSimSubsample,
@@ -232,14 +337,15 @@ func (g physGame) Draw(
 	for x := 0; x < g.World.W; x++ {
 		for y := 0; y < g.World.H; y++ {
 			if true == g.World.Spawner[x][y] {
-				g.ToolImg.Set(x, y,
-					color.RGBA{
-						spawnerR,
-						spawnerG,
-						spawnerB,
-						spawnerA})
+				g.ToolImg.Set(x, y, g.spawnerColor(x, y))
 				continue
 			}
+			if core.EmitterNone != g.World.Emitter[x][y] {
+				g.ToolImg.Set(x, y, emitterColor(g.World.Emitter[x][y]))
+			}
+			if core.LockNone != g.World.Lock[x][y] && g.isLockOutline(x, y) {
+				g.ToolImg.Set(x, y, lockColor(g.World.Lock[x][y]))
+			}
 
 			g.WorldImg.Set(x, y, getDotColor(x, y))
 			drawDotGlow(x, y)
@@ -248,31 +354,79 @@ func (g physGame) Draw(
 
 	radius := 0
 	switch extra.Tool(g.Toolbox.Cursor) {
-	case extra.Brush:
+	case extra.Brush: fallthrough
+	case extra.Line: fallthrough
+	case extra.Mixture:
 		radius = g.BrushRadius
 	case extra.Eraser:
 		radius = g.EraserRadius
+	case extra.Hammer:
+		radius = g.HammerRadius
+	case extra.Lock:
+		radius = g.LockRadius
 	case extra.Heater: fallthrough
-	case extra.Cooler:
+	case extra.Cooler: fallthrough
+	case extra.Igniter:
 		radius = g.ThermoRadius
 	}
 
-	thX, thY := ebiten.CursorPosition()
-	thX = ((thX - g.WorldX) / g.WorldScale) - radius
-	thY = ((thY - g.WorldY) / g.WorldScale) - radius
-	thX2 := thX + radius * 2 + 1
-	thY2 := thY + radius * 2 + 1
-	for x := thX; x < thX2; x++ {
-		for y := thY; y < thY2; y++ {
-			g.ToolImg.Set(x, y,
-				color.RGBA{
-					toolHoverR,
-					toolHoverG,
-					toolHoverB,
-					toolHoverA})
+	drawPlainHover := func(x, y int) {
+		g.ToolImg.Set(x, y,
+			color.RGBA{
+				toolHoverR,
+				toolHoverG,
+				toolHoverB,
+				toolHoverA})
+	}
+	drawHover := func(x, y int) {
+		g.mirrorCells(x, y, drawPlainHover)
+	}
+
+	thX, thY := g.HoverPos()
+	if g.Stroke.Active && isShapeTool(extra.Tool(g.Toolbox.Cursor)) {
+		g.shapeCells(drawHover)
+	} else if extra.Brush == extra.Tool(g.Toolbox.Cursor) {
+		g.brushCells(thX, thY, drawHover)
+	} else if extra.Fill == extra.Tool(g.Toolbox.Cursor) {
+		fillCells(&g.World, thX, thY, drawHover)
+	} else if extra.Text == extra.Tool(g.Toolbox.Cursor) {
+		g.textCells(thX, thY, drawHover)
+	} else if extra.Select == extra.Tool(g.Toolbox.Cursor) {
+		// only pasting is mirrored, not selecting
+		if g.Pasting {
+			g.drawSelect(drawHover)
+		} else {
+			g.drawSelect(drawPlainHover)
+		}
+	} else if extra.Eyedropper == extra.Tool(g.Toolbox.Cursor) {
+		drawPlainHover(thX, thY)
+	} else if extra.Prefab == extra.Tool(g.Toolbox.Cursor) &&
+	          g.CurPrefab() != nil {
+		g.drawGhost(g.CurPrefab().Clip, drawHover)
+	} else {
+		thX -= radius
+		thY -= radius
+		thX2 := thX + radius * 2 + 1
+		thY2 := thY + radius * 2 + 1
+		for x := thX; x < thX2; x++ {
+			for y := thY; y < thY2; y++ {
+				drawHover(x, y)
+			}
 		}
 	}
 
+	g.drawSparks()
+	g.drawMirrorAxes()
+
+	if g.WCursorShown {
+		g.ToolImg.Set(int(g.WCursorX), int(g.WCursorY),
+			color.RGBA{
+				wCursorR,
+				wCursorG,
+				wCursorB,
+				wCursorA})
+	}
+
 	opt.GeoM.Reset()
 	opt.GeoM.Scale(float64(g.WorldScale), float64(g.WorldScale))
 	opt.GeoM.Translate(float64(g.WorldX), float64(g.WorldY))
@@ -282,29 +436,50 @@ func (g physGame) Draw(
 	drawGlowImg()
 
 	screen.DrawImage(g.ToolImg, &opt)
+
+	if g.Overlay {
+		g.drawOverlay(screen)
+	}
+	if extra.Spawner == extra.Tool(g.Toolbox.Cursor) {
+		g.drawSpawnerInfo(screen)
+	}
+	g.drawEmitterInfo(screen)
+	g.drawThermoInfo(screen)
+	if extra.Lock == extra.Tool(g.Toolbox.Cursor) {
+		g.drawLockInfo(screen)
+	}
+	if extra.Brush == extra.Tool(g.Toolbox.Cursor) {
+		g.drawBrushInfo(screen)
+	}
+	if extra.Text == extra.Tool(g.Toolbox.Cursor) {
+		g.drawTextInfo(screen)
+	}
+	g.drawHotbar(screen)
+	if g.Confirm != nil {
+		g.drawConfirm(screen)
+	}
+	if g.TextInput != nil {
+		g.drawTextInput(screen)
+	}
 }
 
+// Returns whether a TileSet was clicked.
+// If not, the current tool is used at the clicked world position.
 func (g *physGame) HandleClick(
-) {
+	mX, mY int,
+) bool {
 	var (
 		clicked  bool
-		mX, mY   int
 		wX, wY   int
 		prevTool extra.Tool
 	)
 
-	mX, mY = ebiten.CursorPosition()
 	prevTool = extra.Tool(g.Toolbox.Cursor)
 
 	clicked = g.Toolbox.HandleClick(mX, mY)
 	if clicked {
-		switch (prevTool) {
-		case extra.Brush:
-			g.BrushMat = g.Matbox.Cursor
-
-		case extra.Spawner:
-			g.SpawnerMat = g.Matbox.Cursor
-		}
+		g.CancelStroke()
+		g.SaveMatboxCursor(prevTool)
 		g.UpdateMatbox()
 	}
 
@@ -312,55 +487,52 @@ func (g *physGame) HandleClick(
 		clicked = g.Matbox.HandleClick(mX, mY)
 	}
 
+	if clicked {
+		return true
+	}
+
 	wX = (mX - g.WorldX) / g.WorldScale
 	wY = (mY - g.WorldY) / g.WorldScale
+	g.UseTool(wX, wY)
 
-	if !clicked &&
-	   wX >= 0 && wX < g.World.W &&
-	   wY >= 0 && wY < g.World.H {
-		curTool := extra.Tool(g.Toolbox.Cursor)
-
-		switch curTool {
-		case extra.Brush:
-			g.World.UseBrush(
-				mat.Mat(g.Matbox.VisibleTiles[g.Matbox.Cursor]),
-				g.Temperature,
-				wX,
-				wY,
-				g.BrushRadius)
-
-		case extra.Spawner:
-			g.World.Spawner[wX][wY] = true
-			g.World.SpwnMat[wX][wY] =
-				mat.Mat(g.Matbox.VisibleTiles[g.Matbox.Cursor])
-
-		case extra.Eraser:
-			g.World.UseEraser(wX, wY, g.EraserRadius)
+	return false
+}
 
-		case extra.Heater:
-			g.World.UseHeater(heaterDelta, wX, wY, g.ThermoRadius)
+func (g *physGame) HandleMouse(
+) {
+	mX, mY := ebiten.CursorPosition()
+
+	if mX != g.MouseX || mY != g.MouseY {
+		g.MouseX = mX
+		g.MouseY = mY
+		g.CursorX = mX
+		g.CursorY = mY
+		g.WCursorShown = false
+	}
 
-		case extra.Cooler:
-			g.World.UseCooler(heaterDelta, wX, wY, g.ThermoRadius)
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		g.CursorX = mX
+		g.CursorY = mY
+		g.HandleClick(mX, mY)
+	} else if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
+		g.EndStroke()
+	}
 
-		default:
-			panic("Used unknown tool " + curTool.String())
-		}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) &&
+	   g.InWorld(mX, mY) {
+		g.Sample((mX - g.WorldX) / g.WorldScale,
+		         (mY - g.WorldY) / g.WorldScale,
+		         extra.Tool(g.Toolbox.Cursor))
 	}
+
+	_, delta := ebiten.Wheel()
+	g.HandleWheel(mX, mY, int(delta))
 }
 
 func (g *physGame) HandleWheel(
+	mX, mY int,
+	delta  int,
 ) {
-	var (
-		mX, mY int
-		delta int
-		target *int
-	)
-
-	mX, mY = ebiten.CursorPosition()
-	_, tmp := ebiten.Wheel()
-	delta = int(tmp)
-
 	if 0 == delta {
 		return
 	}
@@ -371,27 +543,197 @@ func (g *physGame) HandleWheel(
 	if g.Matbox.HandleWheel(mX, mY, delta) {
 		return
 	}
-	if mX >= g.WorldX && mX < g.WorldX + g.World.W * g.WorldScale &&
-	   mY >= g.WorldY && mY < g.WorldY + g.World.H * g.WorldScale {
-		switch extra.Tool(g.Toolbox.Cursor) {
-		case extra.Brush:
-			target = &g.BrushRadius
-		case extra.Eraser:
-			target = &g.EraserRadius
-		case extra.Heater: fallthrough
-		case extra.Cooler:
-			target = &g.ThermoRadius
-		default:
-			return
+	if g.InWorld(mX, mY) {
+		g.ChangeRadius(delta)
+		return
+	}
+}
+
+// Changes the radius of the current tool, if it has one.
+func (g *physGame) ChangeRadius(
+	delta int,
+) {
+	var target = g.toolRadius(extra.Tool(g.Toolbox.Cursor))
+
+	if target == nil {
+		return
+	}
+	*target += delta
+	if *target > maxRadius {
+		*target = maxRadius
+	} else if *target < 0 {
+		*target = 0
+	}
+}
+
+// Returns the world position the current tool would be used at.
+func (g physGame) HoverPos(
+) (int, int) {
+	if g.WCursorShown {
+		return int(g.WCursorX), int(g.WCursorY)
+	}
+
+	return (g.CursorX - g.WorldX) / g.WorldScale,
+	       (g.CursorY - g.WorldY) / g.WorldScale
+}
+
+// Takes screen coordinates.
+func (g physGame) InWorld(
+	x, y int,
+) bool {
+	return x >= g.WorldX && x < g.WorldX + g.World.W * g.WorldScale &&
+	       y >= g.WorldY && y < g.WorldY + g.World.H * g.WorldScale
+}
+
+// Uses the current tool at the given world coordinates.
+// Every input method ends up here, so tools behave the same for all of them.
+func (g *physGame) UseTool(
+	wX, wY int,
+) {
+	if wX < 0 || wX >= g.World.W ||
+	   wY < 0 || wY >= g.World.H {
+		return
+	}
+
+	curTool := extra.Tool(g.Toolbox.Cursor)
+	strokeStart := !g.Stroke.Active
+
+	if strokeStart {
+		g.Stroke.Active = true
+		g.Stroke.StartX = wX
+		g.Stroke.StartY = wY
+		g.Stroke.X = wX
+		g.Stroke.Y = wY
+		g.History.Begin(&g.World)
+	}
+
+	switch curTool {
+	case extra.Brush:
+		g.strokeSegment(wX, wY, g.PaintBrush)
+
+	case extra.Spawner:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.Touch(&g.World, x, y)
+			g.World.SetSpawner(g.CurMat(),
+			                   g.SpawnerT,
+			                   x,
+			                   y,
+			                   g.SpawnerInterval,
+			                   g.SpawnerChance,
+			                   g.SpawnerBurst)
+		})
+
+	case extra.Eraser:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.TouchArea(&g.World, x, y, g.EraserRadius)
+			g.World.UseEraser(x, y, g.EraserRadius)
+		})
+
+	case extra.Hammer:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.TouchArea(&g.World, x, y, g.HammerRadius)
+			g.World.UseHammer(x, y, g.HammerRadius)
+		})
+
+	case extra.Lock:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.TouchArea(&g.World, x, y, g.LockRadius)
+			g.World.UseLock(g.LockMode, x, y, g.LockRadius)
+		})
+
+	case extra.Heater:
+		g.strokeArea(wX, wY, g.ThermoRadius, func(x, y int) {
+			g.History.Touch(&g.World, x, y)
+			if g.ThermoSet {
+				g.World.UseThermostat(g.ThermoSetT, x, y, 0)
+			} else {
+				g.World.UseHeater(g.HeaterDelta, x, y, 0)
+			}
+		})
+
+	case extra.Cooler:
+		g.strokeArea(wX, wY, g.ThermoRadius, func(x, y int) {
+			g.History.Touch(&g.World, x, y)
+			if g.ThermoSet {
+				g.World.UseThermostat(g.ThermoSetT, x, y, 0)
+			} else {
+				g.World.UseCooler(g.HeaterDelta, x, y, 0)
+			}
+		})
+
+	case extra.Igniter:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.TouchArea(&g.World, x, y, g.ThermoRadius)
+			g.AddSparks(x, y, g.ThermoRadius)
+			g.World.UseIgniter(x, y, g.ThermoRadius)
+		})
+
+	case extra.Line: fallthrough
+	case extra.Rectangle: fallthrough
+	case extra.Circle:
+		// placed by EndStroke, on release
+
+	case extra.Drain: fallthrough
+	case extra.HeatSource: fallthrough
+	case extra.HeatSink:
+		g.strokeSegment(wX, wY, func(x, y int) {
+			g.History.Touch(&g.World, x, y)
+			g.PlaceEmitter(x, y)
+		})
+
+	case extra.Mixture:
+		r := g.CurRecipe()
+		if r == nil {
+			break
 		}
-		*target += delta
-		if *target > maxRadius {
-			*target = maxRadius
-		} else if *target < 0 {
-			*target = 0
+		g.strokeArea(wX, wY, g.BrushRadius, func(x, y int) {
+			g.History.Touch(&g.World, x, y)
+			g.World.UseBrush(r.Pick(), g.Temperature, x, y, 0)
+		})
+
+	case extra.Prefab:
+		if strokeStart && g.CurPrefab() != nil {
+			g.Place(g.CurPrefab().Clip, wX, wY)
 		}
-		return
+
+	case extra.Select:
+		if strokeStart && g.Pasting {
+			g.Paste(wX, wY)
+			g.Stroke.Pasted = true
+		}
+
+	case extra.Eyedropper:
+		if strokeStart {
+			g.UseEyedropper(wX, wY)
+		}
+
+	case extra.Text:
+		if strokeStart {
+			g.StampText(wX, wY)
+		}
+
+	case extra.Fill:
+		if strokeStart {
+			g.mirrorCells(wX, wY, func(mX, mY int) {
+				fillCells(&g.World, mX, mY, func(x, y int) {
+					g.History.Touch(&g.World, x, y)
+				})
+				g.World.UseFill(g.CurMat(), g.Temperature, mX, mY)
+			})
+		}
+
+	default:
+		panic("Used unknown tool " + curTool.String())
 	}
+
+	g.Stroke.X = wX
+	g.Stroke.Y = wY
+}
+
+// Returns the material selected in the Matbox.
+func (g physGame) CurMat(
+) mat.Mat {
+	return mat.Mat(g.Matbox.VisibleTiles[g.Matbox.Cursor])
 }
 
 func (g physGame) Layout(
@@ -409,7 +751,59 @@ func (g *physGame) Update(
 
 	keys = inpututil.AppendJustPressedKeys(keys)
 
+	typing := g.Confirm != nil || g.TextInput != nil
+
+	if g.Confirm != nil {
+		for i := 0; i < len(keys) && g.Confirm != nil; i++ {
+			g.HandleConfirmKey(keys[i])
+		}
+		keys = nil
+	} else if g.TextInput != nil {
+		g.HandleTextInput(keys)
+		keys = nil
+	}
+
 	for i := 0; i < len(keys); i++ {
+		g.HandleWorldCmdKey(keys[i])
+		if g.Confirm != nil {
+			break
+		}
+		g.HandlePresetKey(keys[i])
+		g.HandleMirrorKey(keys[i])
+		g.HandleOverlayKey(keys[i])
+		g.HandleTypedTKey(keys[i])
+
+		switch extra.Tool(g.Toolbox.Cursor) {
+		case extra.Select:
+			g.HandleSelectKey(keys[i])
+
+		case extra.Spawner:
+			g.HandleSpawnerKey(keys[i])
+
+		case extra.HeatSource: fallthrough
+		case extra.HeatSink:
+			g.HandleHeatKey(keys[i])
+
+		case extra.Lock:
+			g.HandleLockKey(keys[i])
+
+		case extra.Text:
+			g.HandleTextKey(keys[i])
+			g.HandleThermoKey(keys[i])
+
+		case extra.Brush:
+			g.HandleBrushKey(keys[i])
+			fallthrough
+		case extra.Line: fallthrough
+		case extra.Rectangle: fallthrough
+		case extra.Circle: fallthrough
+		case extra.Fill: fallthrough
+		case extra.Mixture: fallthrough
+		case extra.Heater: fallthrough
+		case extra.Cooler:
+			g.HandleThermoKey(keys[i])
+		}
+
 		switch (keys[i]) {
 		case ebiten.KeyEscape:
 			return ebiten.Termination
@@ -418,30 +812,10 @@ func (g *physGame) Update(
 			g.Paused = !g.Paused
 
 		case ebiten.KeyArrowLeft:
-			if g.Toolbox.Cursor > 0 {
-				switch extra.Tool(g.Toolbox.Cursor) {
-				case extra.Brush:
-					g.BrushMat = g.Matbox.Cursor
-
-				case extra.Spawner:
-					g.SpawnerMat = g.Matbox.Cursor
-				}
-				g.Toolbox.Cursor--
-				g.UpdateMatbox()
-			}
+			g.SelectTool(g.Toolbox.Cursor - 1)
 
 		case ebiten.KeyArrowRight:
-			if g.Toolbox.Cursor < len(g.Toolbox.VisibleTiles) - 1 {
-				switch extra.Tool(g.Toolbox.Cursor) {
-				case extra.Brush:
-					g.BrushMat = g.Matbox.Cursor
-
-				case extra.Spawner:
-					g.SpawnerMat = g.Matbox.Cursor
-				}
-				g.Toolbox.Cursor++
-				g.UpdateMatbox()
-			}
+			g.SelectTool(g.Toolbox.Cursor + 1)
 
 		case ebiten.KeyArrowUp:
 			if g.Matbox.Cursor > 0 {
@@ -463,6 +837,28 @@ func (g *physGame) Update(
 				g.SimSubsample *= 2
 			}
 
+		case ebiten.KeyY:
+			if ebiten.IsKeyPressed(ebiten.KeyControl) {
+				g.CancelStroke()
+				g.History.Redo(&g.World)
+			}
+
+		case ebiten.KeyZ:
+			if ebiten.IsKeyPressed(ebiten.KeyControl) {
+				g.CancelStroke()
+				if ebiten.IsKeyPressed(ebiten.KeyShift) {
+					g.History.Redo(&g.World)
+				} else {
+					g.History.Undo(&g.World)
+				}
+			}
+
+		case ebiten.KeyF:
+			g.ShapeFilled = !g.ShapeFilled
+
+		case ebiten.KeyU:
+			g.Celsius = !g.Celsius
+
 		case ebiten.KeyT:
 			g.ThVision = !g.ThVision
 			if true == g.ThVision {
@@ -477,13 +873,24 @@ func (g *physGame) Update(
 		}
 	}
 
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		g.HandleClick()
+	g.Touches = pollTouches(g.Touches)
+	if g.Confirm != nil {
+		// no tool use, until the world command is confirmed or cancelled
+	} else if len(g.Touches) > 0 || g.Touch.Active {
+		g.HandleTouches(g.Touches)
+	} else {
+		g.HandleMouse()
+	}
+	if !typing {
+		g.HandleWCursorKeys()
+		g.HandleGamepads()
+	}
+	if extra.Text == extra.Tool(g.Toolbox.Cursor) {
+		g.UpdateTextStamp()
 	}
 
-	g.HandleWheel()
-
-	g.World.Update(g.Temperature)
+	g.World.Update()
+	g.UpdateSparks()
 
 	if !g.Paused {
 		if g.TsSinceSim >= g.SimSubsample {
@@ -497,14 +904,65 @@ func (g *physGame) Update(
 	return nil
 }
 
+// Switches to the given tool, if it exists,
+// while the material of the previous tool is remembered.
+func (g *physGame) SelectTool(
+	tool int,
+) {
+	if tool < 0 || tool >= len(g.Toolbox.VisibleTiles) ||
+	   tool == g.Toolbox.Cursor {
+		return
+	}
+
+	g.CancelStroke()
+	g.SaveMatboxCursor(extra.Tool(g.Toolbox.Cursor))
+	g.Toolbox.Cursor = tool
+	g.UpdateMatbox()
+}
+
+// Remembers what the given tool had selected in the Matbox,
+// for when UpdateMatbox switches back to it.
+func (g *physGame) SaveMatboxCursor(
+	tool extra.Tool,
+) {
+	switch tool {
+	case extra.Brush: fallthrough
+	case extra.Line: fallthrough
+	case extra.Rectangle: fallthrough
+	case extra.Circle: fallthrough
+	case extra.Fill: fallthrough
+	case extra.Text:
+		g.BrushMat = g.Matbox.Cursor
+
+	case extra.Spawner:
+		g.SpawnerMat = g.Matbox.Cursor
+
+	case extra.Prefab:
+		g.PrefabCur = g.Matbox.Cursor
+
+	case extra.Mixture:
+		g.RecipeCur = g.Matbox.Cursor
+
+	case extra.Drain:
+		g.DrainMat = g.Matbox.Cursor
+	}
+}
+
 func (g *physGame) UpdateMatbox(
 ) {
 	var tiles = make([]int, 0)
 
+	g.UpdateMatImgs()
 	g.Matbox.Scroll = 0
+	g.Matbox.Tiles = g.MatImgs
 
 	switch(extra.Tool(g.Toolbox.Cursor)) {
-	case extra.Brush:
+	case extra.Brush: fallthrough
+	case extra.Line: fallthrough
+	case extra.Rectangle: fallthrough
+	case extra.Circle: fallthrough
+	case extra.Fill: fallthrough
+	case extra.Text:
 		for i := firstRealMat; i < mat.Mat(mat.MatCount); i++ {
 			tiles = append(tiles, int(i))
 		}
@@ -514,7 +972,7 @@ func (g *physGame) UpdateMatbox(
 	case extra.Spawner:
 		tiles = append(tiles, int(mat.None))
 		for i := firstRealMat; i < mat.Mat(mat.MatCount); i++ {
-			state := mat.ThermoToState(i, g.Temperature)
+			state := mat.ThermoToState(i, g.SpawnerT)
 			if state != mat.Static {
 				tiles = append(tiles, int(i))
 			}
@@ -522,9 +980,41 @@ func (g *physGame) UpdateMatbox(
 		g.Matbox.VisibleTiles = tiles
 		g.Matbox.Cursor = g.SpawnerMat
 
+	case extra.Drain:
+		for i := mat.None; i < mat.Mat(mat.MatCount); i++ {
+			if mat.None == i || i >= firstRealMat {
+				tiles = append(tiles, int(i))
+			}
+		}
+		g.Matbox.VisibleTiles = tiles
+		g.Matbox.Cursor = g.DrainMat
+
+	case extra.Prefab:
+		for i := 0; i < len(g.Prefabs); i++ {
+			tiles = append(tiles, i)
+		}
+		g.Matbox.Tiles = g.PrefabImgs
+		g.Matbox.VisibleTiles = tiles
+		g.Matbox.Cursor = min(g.PrefabCur, len(tiles) - 1)
+
+	case extra.Mixture:
+		for i := 0; i < len(g.Recipes); i++ {
+			tiles = append(tiles, i)
+		}
+		g.Matbox.Tiles = g.RecipeImgs
+		g.Matbox.VisibleTiles = tiles
+		g.Matbox.Cursor = min(g.RecipeCur, len(tiles) - 1)
+
 	case extra.Eraser: fallthrough
 	case extra.Heater: fallthrough
-	case extra.Cooler:
+	case extra.Cooler: fallthrough
+	case extra.Select: fallthrough
+	case extra.HeatSource: fallthrough
+	case extra.HeatSink: fallthrough
+	case extra.Hammer: fallthrough
+	case extra.Igniter: fallthrough
+	case extra.Eyedropper: fallthrough
+	case extra.Lock:
 		g.Matbox.VisibleTiles = nil
 		g.Matbox.Cursor = -1
 	}
@@ -548,6 +1038,35 @@ Options:
     -a -about
         prints program name, version, license and repository information then exits
 
+    -assets DIR
+        loads images and the font from the given directory first,
+        falling back to the built-in ones for every file not found there,
+        or that can't be loaded, which is warned about
+        files are named like the built-in ones, such as "tool_Brush.png"
+
+    -top -bottom -left -right MODE
+        sets what happens to dots at that edge of the world
+        "wall" keeps them inside
+        "void" deletes dots leaving the world, which only gases do at the top
+        "wrap" brings them back in at the opposite edge,
+        which is in effect for both edges, if either of them wraps
+        "fixed:T" is a wall, that heats or cools dots towards T,
+        which is in Kelvin, or in Celsius when followed by a C, like "fixed:20C"
+        default: wall
+
+    -edges MODE
+        sets the MODE of all four edges, see above
+
+    -font PATH
+        loads the UI font from the given file instead of the built-in one
+        either a BDF font or a PNG glyph sheet,
+        which may be accompanied by a ".metrics" file of the same name
+        if it can't be loaded, a warning is printed and the default font is used
+
+    -grid NUMBER
+        sets every how many cells the overlay draws a grid line
+        default: %v
+
     -H -height NUMBER
         sets the window height
         default: %v
@@ -558,6 +1077,20 @@ Options:
     -noborder
         removes window decoration from window
 
+    -prefabs DIR
+        sets the directory prefabs are loaded from and saved to
+        default: %v
+
+    -presets PATH
+        sets the file presets are loaded from and saved to
+        each line is a preset like "1: Brush, Water, 3, 278.15, all"
+        default: %v
+
+    -recipes PATH
+        sets the file mixture recipes are loaded from and saved to
+        each line is a recipe like "Thermite: 75 Iron Oxide, 25 Aluminum"
+        default: %v
+
     -scale -winscale -windowscale
         sets the overall graphical scale
         default: %v
@@ -565,11 +1098,21 @@ Options:
     -tallui
         overrides automatic layout determination, and sets tall ui
 
-    -temperature
-        sets the temperature of every new dot in Kelvin
+    -temperature TEMPERATURE
+        sets the temperature of every new dot,
+        in Kelvin or, if followed by C, in degree Celsius, like "20C"
         0 °C == %v K
         default: %v
 
+    -undomem NUMBER
+        sets how many MiB the undo history may use,
+        before the oldest strokes are forgotten
+        default: %v
+
+    -undosim
+        makes undo also revert everything the simulation did since the stroke,
+        by keeping a copy of the whole world per stroke
+
     -tickrate NUMBER
         sets the tickrate (ticks per second),
         which also effects simulation speed
@@ -611,18 +1154,209 @@ Default keybinds:
         Increase and decrease the simulation speed respectively
         default: %.2f updates per second
 
+    H J K L
+        move the world cursor left, down, up and right
+        hold Shift to move faster, or Control to move to the edge
+
+    E
+        use the current tool at the world cursor
+
+    C and V
+        decrease and increase the tool radius
+
+    F1
+        clear the whole world, except for locked areas
+
+    F2
+        remove all dots of the material under the cursor
+
+    F3
+        remove all spawners
+
+    F4
+        set every dot to a temperature, which is typed and then confirmed
+        in Kelvin, or in Celsius when followed by a C
+
+    F5
+        reset the oxidation and dissolution of every dot
+
+    Enter and ESC
+        confirm or cancel what F1 to F5 asked for
+        a confirmed world command can be undone like a tool stroke
+
+    1 to 0
+        switch to the tool and settings of that preset,
+        as shown in the hotbar at the bottom of the world
+
+    Control + 1 to 0
+        store the current tool and its material, radius, temperature
+        and the brush mode as that preset
+
+    Control + Z
+        undo the last tool stroke
+
+    Control + Y or Control + Shift + Z
+        redo the last undone tool stroke
+
+    F
+        toggle between filled and outlined rectangles and circles
+
+    Control + C and Control + X
+        copy or cut the selection, while the Select tool is used
+
+    Control + V
+        start or stop pasting, while the Select tool is used
+        the next click places the copy
+
+    Control + S
+        save the selection as a prefab, while the Select tool is used
+        type its name, then Enter saves it, and ESC cancels
+        prefabs are placed with the Prefab tool
+
+    Control + M
+        save the ratio of the materials in the selection as a recipe,
+        while the Select tool is used
+        recipes are painted with the Mixture tool
+
+    R
+        rotate the copy clockwise, before pasting
+
+    X and Y
+        flip the copy horizontally or vertically, before pasting
+
+    [ and ]
+        decrease and increase the temperature of new spawners,
+        while the Spawner tool is used, hold Shift for bigger steps
+
+    Comma and Period
+        decrease and increase the chance of new spawners to spawn each time
+
+    Semicolon and Apostrophe
+        decrease and increase the ticks between spawns of new spawners
+
+    B
+        cycle how many times new spawners spawn, before they vanish
+
+    N with the Brush tool
+        cycle between painting over everything, only into empty space,
+        and only over the material to replace
+
+    G with the Brush tool
+        make the material under the cursor the one to replace
+
+    O with the Brush tool
+        switch between a square and round brush
+
+    Comma and Period with the Brush tool
+        decrease and increase how many dots the brush sprays
+
+    [ and ] with the Brush, Line, Rectangle, Circle, Fill, Mixture or Text tool
+        decrease and increase the temperature of new dots,
+        hold Shift for bigger steps
+
+    [ and ] with the Heater or Cooler tool
+        decrease and increase how much they heat or cool each tick,
+        or the temperature they set dots to
+
+    M with the Heater or Cooler tool
+        switch between heating or cooling, and setting an exact temperature
+
+    U
+        switch showing temperatures between Celsius and Kelvin
+
+    Backslash
+        type the temperature of the current tool, like "300" or "20C",
+        which is in Kelvin, or in Celsius when followed by a C,
+        Enter takes it, and ESC keeps the old one
+        the Heater, Cooler, Heat Source and Heat Sink then set or hold it
+
+    [ and ] with the Heat Source or Heat Sink tool
+        decrease and increase the temperature they hold dots at,
+        or the temperature they add or remove each tick
+
+    M with the Heat Source or Heat Sink tool
+        switch new heat sources and sinks between holding a temperature
+        and adding or removing temperature each tick
+
+    M with the Lock tool
+        cycle between locking dots against tools,
+        freezing them, so the simulation leaves them alone as well,
+        and unlocking them
+
+    Enter with the Text tool
+        type a new text to write into the world,
+        Enter again takes it, and ESC keeps the old one
+
+    Comma and Period with the Text tool
+        decrease and increase the scale of the text
+
+    Q
+        cycle between not mirroring, mirroring left and right,
+        up and down, and both, which makes every tool work symmetrically
+
+    W
+        place the mirror axes through the cell under the cursor,
+        hold Shift to place them at its top left edges instead
+
     T
         Toggle thermal vision (grayscale displaying %v to %v degree Celsius)
 
+    I
+        toggle the overlay, which shows a grid, rulers along the world edges,
+        and the coordinates of the cursor and the selection
+
+    Shift + I
+        cycle the grid size of the overlay between 5, 10, 20 and 50 cells
+
     Wheel Up and Down
         Scrolls a TileSet or increases/decreases the tool radius,
         depending on where the mouse is at the time
+
+    Right click
+        adopts the material and temperature of the dot under the mouse,
+        for the Spawner or a tool that places dots
+        the Eyedropper tool does the same with a left click
+
+Touch controls:
+
+    Tap and drag
+        uses the current tool, or selects a tile of a TileSet
+
+    Two-finger pinch
+        increases/decreases the tool radius, while over the world
+
+    Two-finger drag
+        scrolls the material TileSet
+
+Gamepad controls:
+
+    Left stick
+        moves the world cursor
+
+    Left and Right trigger
+        uses the current tool at the world cursor
+
+    Left shoulder
+        cycles through tools
+
+    Right shoulder
+        cycles through materials
+
+    Right stick Up and Down
+        increases/decreases the tool radius
+
+    Start
+        pause world
 `;
 
-func genMatImages(t float64) []*ebiten.Image {
+func genMatImages(
+	assets assetFS,
+	t      float64,
+) ([]*ebiten.Image, error) {
 	var (
 		bgImgs      [mat.StateCount]*ebiten.Image
-		matBgPrefix string = "assets/matbg_"
+		err         error
+		matBgPrefix string = "matbg_"
 		matBgPaths = [mat.StateCount]string{
 			"static",
 			"grain",
@@ -636,23 +1370,18 @@ func genMatImages(t float64) []*ebiten.Image {
 		symbolImgH  int
 	)
 
-	imgopen := func(path string) *ebiten.Image {
-		img, _, err := ebitenutil.NewImageFromFileSystem(pngs, path)
-		if err != nil {
-			panic(err)
-		}
-		return img
-	}
-
 	for i := 0; i < len(bgImgs); i++ {
 		path := matBgPrefix + matBgPaths[i] + postfix
-		bgImgs[i] = imgopen(path)
+		bgImgs[i], err = assets.Image(path, pngSize, pngSize)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	for i := mat.None; i < mat.Mat(mat.MatCount); i++ {
 		symbolImg = ebiten.NewImage(
 			ui.DrawnTextLen(mat.Symbol(i), uiSymbolFontSpacing),
-			ui.FontCharMaxH)
+			ui.DrawnTextH(mat.Symbol(i)))
 		ui.DrawText(symbolImg, 0, 0, mat.Symbol(i), uiSymbolFontSpacing)
 		opt := ebiten.DrawImageOptions{}
 		img := ebiten.NewImage(pngSize * pngScale, pngSize * pngScale)
@@ -683,26 +1412,18 @@ func genMatImages(t float64) []*ebiten.Image {
 		ret = append(ret, img)
 	}
 
-	return ret
+	return ret, nil
 }
 
-func genToolImages() []*ebiten.Image {
+func genToolImages(
+	assets assetFS,
+) ([]*ebiten.Image, error) {
 	var (
-		prefix = "assets/tool_"
+		prefix = "tool_"
 		postfix = ".png"
 		ret = make([]*ebiten.Image, extra.ToolCount)
 	)
 
-	imgopenAndDraw := func(path string, dest *ebiten.Image) {
-		opt := ebiten.DrawImageOptions{}
-		img, _, err := ebitenutil.NewImageFromFileSystem(pngs, path)
-		if err != nil {
-			panic(err)
-		}
-		opt.GeoM.Scale(float64(pngScale), float64(pngScale))
-		dest.DrawImage(img, &opt)
-	}
-
 	for i := 0; i < extra.ToolCount; i++ {
 		ret[i] = ebiten.NewImage(pngSize * pngScale, pngSize * pngScale)
 
@@ -716,21 +1437,45 @@ func genToolImages() []*ebiten.Image {
 
 		path := prefix + extra.Tool(i).String() + postfix
 
-		imgopenAndDraw(path, ret[i])
+		img, err := assets.Image(path, pngSize, pngSize)
+		if err != nil {
+			return nil, err
+		}
+		opt := ebiten.DrawImageOptions{}
+		opt.GeoM.Scale(float64(pngScale), float64(pngScale))
+		ret[i].DrawImage(img, &opt)
 	}
 
-	return ret
+	return ret, nil
 }
 
 func handleArgs(
+	assetDir    *string,
+	boundaries  *[core.EdgeCount]boundary,
+	fontPath    *string,
+	gridSize    *int,
 	layout      *uiLayout,
+	prefabDir   *string,
+	presetPath  *string,
+	recipePath  *string,
 	temperature *float64,
 	tickrate    *int,
+	undoMem     *int,
+	undoSim     *bool,
 	winW        *int,
 	winH        *int,
 	winScale    *int,
 	worldScale  *int,
 ) bool {
+	argToStr := func(i int) string {
+		if len(os.Args) <= i + 1 {
+			panic("The argument \"" +
+				os.Args[i] +
+				"\" needs to be followed by a value");
+		}
+		return os.Args[i + 1]
+	}
+
 	argToInt := func(i int) int {
 		if len(os.Args) <= i + 1 {
 			panic("The argument \"" +
@@ -747,6 +1492,17 @@ func handleArgs(
 		return n
 	}
 
+	argToBoundary := func(i int) boundary {
+		b, err := parseBoundary(argToStr(i))
+		if err != nil {
+			panic("The value for \"" +
+				os.Args[i] +
+				"\" is invalid: " +
+				err.Error())
+		}
+		return b
+	}
+
 	for i := 1; i < len(os.Args); i++ {
 		switch os.Args[i] {
 		case "-a": fallthrough
@@ -758,19 +1514,56 @@ func handleArgs(
 			           AppLicenseUrl)
 			return false
 
+		case "-assets":
+			*assetDir = argToStr(i)
+			i++
+
+		case "-bottom":
+			boundaries[core.Bottom] = argToBoundary(i)
+			i++
+
+		case "-edges":
+			b := argToBoundary(i)
+			for e := 0; e < len(boundaries); e++ {
+				boundaries[e] = b
+			}
+			i++
+
+		case "-font":
+			*fontPath = argToStr(i)
+			i++
+
+		case "-grid":
+			*gridSize = argToInt(i)
+			if *gridSize <= 0 {
+				panic("The value for \"" +
+					os.Args[i] +
+					"\" must be positive")
+			}
+			i++
+
 		case "-H": fallthrough
 		case "-height":
 			*winH = argToInt(i)
 			i++
 
+		case "-left":
+			boundaries[core.Left] = argToBoundary(i)
+			i++
+
 		case "-h": fallthrough
 		case "-help":
 			fmt.Printf(appHelp,
 			           AppName,
+			           stdGridSize,
 			           stdWinH,
+			           stdPrefabDir(),
+			           stdPresetPath(),
+			           stdRecipePath(),
 			           stdWinScale,
 			           celsiusToKelvin,
 			           stdTemperature,
+			           stdUndoMem,
 			           stdTickrate,
 			           stdWinW,
 			           stdWorldScale,
@@ -782,6 +1575,22 @@ func handleArgs(
 		case "-noborder":
 			ebiten.SetWindowDecorated(false)
 
+		case "-prefabs":
+			*prefabDir = argToStr(i)
+			i++
+
+		case "-presets":
+			*presetPath = argToStr(i)
+			i++
+
+		case "-recipes":
+			*recipePath = argToStr(i)
+			i++
+
+		case "-right":
+			boundaries[core.Right] = argToBoundary(i)
+			i++
+
 		case "-scale": fallthrough
 		case "-winscale": fallthrough
 		case "-windowscale":
@@ -792,18 +1601,36 @@ func handleArgs(
 			*layout = tall
 
 		case "-temperature":
-			*temperature = float64(argToInt(i))
-			if *temperature < 0 {
+			t, err := parseTemperature(argToStr(i))
+			if err != nil {
 				panic("The value for \"" +
 					os.Args[i] +
-					"\" must not be negative")
+					"\" is invalid: " +
+					err.Error())
 			}
+			*temperature = t
 			i++
 
 		case "-tickrate":
 			*tickrate = argToInt(i)
 			i++
 
+		case "-top":
+			boundaries[core.Top] = argToBoundary(i)
+			i++
+
+		case "-undomem":
+			*undoMem = argToInt(i)
+			if *undoMem < 0 {
+				panic("The value for \"" +
+					os.Args[i] +
+					"\" must not be negative")
+			}
+			i++
+
+		case "-undosim":
+			*undoSim = true
+
 		case "-v": fallthrough
 		case "-version":
 			fmt.Printf("%v: version %v\n", AppName, AppVersion)
@@ -836,10 +1663,15 @@ func handleArgs(
 func main(
 ) {
 	var (
+		assetDir   string
+		err        error
+		font       *ui.Font
+		fontPath   string
 		g          physGame = newPhysGame()
 		layout     uiLayout
 		tiles      []int
 		tsWide     bool
+		undoMem    int = stdUndoMem
 		winScale   int = stdWinScale
 		winW       int = stdWinW
 		winH       int = stdWinH
@@ -848,14 +1680,21 @@ func main(
 		wW, wH     int
 	)
 
-	ui.Init(pngs, "assets/font.png")
-
 	ebiten.SetFullscreen(true);
 
 	if handleArgs(
+		&assetDir,
+		&g.Boundaries,
+		&fontPath,
+		&g.GridSize,
 		&layout,
+		&g.PrefabDir,
+		&g.PresetPath,
+		&g.RecipePath,
 		&g.Temperature,
 		&g.Tickrate,
+		&undoMem,
+		&g.History.Snapshots,
 		&winW,
 		&winH,
 		&winScale,
@@ -864,6 +1703,32 @@ func main(
 		return
 	}
 
+	g.History.Budget = undoMem * 1024 * 1024
+	g.SpawnerT = g.Temperature
+
+	g.Assets, err = newAssetFS(assetDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not open assets: %v\n", err)
+		return
+	}
+
+	if fontPath != "" {
+		font, err = ui.LoadFont(os.DirFS(filepath.Dir(fontPath)),
+		                        filepath.Base(fontPath))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not load font: %v, "+
+			            "falling back\n", err)
+		}
+	}
+	if font == nil {
+		font, err = g.Assets.Font(uiFontFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load font: %v\n", err)
+		return
+	}
+	ui.SetFont(font)
+
 	if ebiten.IsFullscreen() {
 		screenW, screenH := ebiten.Monitor().Size()
 		g.FrameW = screenW / winScale
@@ -905,12 +1770,33 @@ func main(
 		g.WorldY = 0
 	}
 
+	toolImgs, err := genToolImages(g.Assets)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load tool images: %v\n", err)
+		return
+	}
+	matImgs, err := genMatImages(g.Assets, g.Temperature)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load material images: %v\n", err)
+		return
+	}
+	g.MatImgs = matImgs
+	g.MatImgsT = g.Temperature
+
+	g.Prefabs = loadPrefabs(g.PrefabDir)
+	g.PrefabImgs = genPrefabImages(g.Prefabs)
+
+	g.Presets = loadPresets(g.PresetPath)
+
+	g.Recipes = loadRecipes(g.RecipePath)
+	g.RecipeImgs = genRecipeImages(g.Recipes)
+
 	g.Toolbox = ui.NewTileSetFromImgs(
 		tsWide,
 		uiTileSetW,
 		tbW,
 		tbH,
-		genToolImages())
+		toolImgs)
 	g.Toolbox.Bg = color.RGBA{uiToolBgR, uiToolBgG, uiToolBgB, uiToolBgA}
 
 	for i := 0; i < len(g.Toolbox.Tiles); i++ {
@@ -923,7 +1809,7 @@ func main(
 		uiTileSetW,
 		mbW,
 		mbH,
-		genMatImages(g.Temperature))
+		matImgs)
 	g.Matbox.Bg = color.RGBA{uiMatBgR, uiMatBgG, uiMatBgB, uiMatBgA}
 
 	g.UpdateMatbox()
@@ -937,6 +1823,8 @@ func main(
 	}
 
 	g.World = core.NewWorld(wW, wH, g.Temperature)
+	g.ApplyBoundaries()
+	g.CenterMirror()
 	g.ToolImg = ebiten.NewImage(wW, wH)
 	g.WorldImg = ebiten.NewImage(wW, wH)
 	g.GlowImg = ebiten.NewImage(wW, wH)
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Tools are mirrored by applying them to the mirrored cells as well.
+// Tools with a radius are applied at the mirrored points,
+// which mirrors them just as well, since their footprint is symmetric.
+
+// Which axes tools are mirrored across.
+// The vertical axis mirrors left and right, the horizontal one up and down.
+type mirrorMode int
+
+const (
+	mirrorOff mirrorMode = iota
+	mirrorV
+	mirrorH
+	mirrorBoth
+
+	mirrorModeCount
+)
+
+const (
+	mirrorAxisR = 255
+	mirrorAxisG = 80
+	mirrorAxisB = 200
+	mirrorAxisA = 140
+)
+
+// One way of mirroring a position.
+type mirror struct {
+	FlipX bool
+	FlipY bool
+}
+
+// The ways the current mode mirrors, starting with not mirroring at all.
+func (g physGame) mirrors(
+) []mirror {
+	var ret = []mirror{{}}
+
+	if mirrorV == g.Mirror || mirrorBoth == g.Mirror {
+		ret = append(ret, mirror{FlipX: true})
+	}
+	if mirrorH == g.Mirror || mirrorBoth == g.Mirror {
+		ret = append(ret, mirror{FlipY: true})
+	}
+	if mirrorBoth == g.Mirror {
+		ret = append(ret, mirror{FlipX: true, FlipY: true})
+	}
+
+	return ret
+}
+
+// The axes are kept at twice their position,
+// so they can lie on the center of a cell as well as between two.
+func (g physGame) mirrorPos(
+	m    mirror,
+	x, y int,
+) (int, int) {
+	if m.FlipX {
+		x = g.MirrorX2 - 1 - x
+	}
+	if m.FlipY {
+		y = g.MirrorY2 - 1 - y
+	}
+
+	return x, y
+}
+
+// Visits the cell and each of its mirrored cells once.
+func (g physGame) mirrorCells(
+	x, y  int,
+	visit func(x, y int),
+) {
+	var (
+		ms   = g.mirrors()
+		seen [][2]int
+	)
+
+outer:
+	for i := 0; i < len(ms); i++ {
+		mx, my := g.mirrorPos(ms[i], x, y)
+
+		for j := 0; j < len(seen); j++ {
+			if seen[j] == [2]int{mx, my} {
+				continue outer
+			}
+		}
+		seen = append(seen, [2]int{mx, my})
+
+		visit(mx, my)
+	}
+}
+
+// Places the axes at the center of the world.
+func (g *physGame) CenterMirror(
+) {
+	g.MirrorX2 = g.World.W
+	g.MirrorY2 = g.World.H
+}
+
+// Handles a just pressed key.
+// Q cycles the mirror modes,
+// and W places the axes through the center of the cell under the cursor,
+// or with Shift at its top left edges.
+func (g *physGame) HandleMirrorKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyQ:
+		g.Mirror = (g.Mirror + 1) % mirrorModeCount
+
+	case ebiten.KeyW:
+		x, y := g.HoverPos()
+		if !g.InWorldBounds(x, y) {
+			return
+		}
+
+		if ebiten.IsKeyPressed(ebiten.KeyShift) {
+			g.MirrorX2 = x * 2
+			g.MirrorY2 = y * 2
+		} else {
+			g.MirrorX2 = x * 2 + 1
+			g.MirrorY2 = y * 2 + 1
+		}
+	}
+}
+
+// Marks the axes on ToolImg.
+// An axis between two cells marks both of them.
+func (g physGame) drawMirrorAxes(
+) {
+	var c = color.RGBA{mirrorAxisR, mirrorAxisG, mirrorAxisB, mirrorAxisA}
+
+	if mirrorV == g.Mirror || mirrorBoth == g.Mirror {
+		for y := 0; y < g.World.H; y++ {
+			g.ToolImg.Set(g.MirrorX2 / 2, y, c)
+			g.ToolImg.Set((g.MirrorX2 - 1) / 2, y, c)
+		}
+	}
+
+	if mirrorH == g.Mirror || mirrorBoth == g.Mirror {
+		for x := 0; x < g.World.W; x++ {
+			g.ToolImg.Set(x, g.MirrorY2 / 2, c)
+			g.ToolImg.Set(x, (g.MirrorY2 - 1) / 2, c)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+	"github.com/SchokiCoder/hawps/extra"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// The overlay is drawn on the screen, above the world,
+// so its lines and labels stay sharp at any world scale.
+// The x ruler runs along the top and the y ruler along the right edge,
+// while the status is in the bottom right corner.
+
+const (
+	stdGridSize = 10
+
+	gridR = 255
+	gridG = 255
+	gridB = 255
+	gridA = 48
+
+	rulerR = 255
+	rulerG = 255
+	rulerB = 255
+	rulerA = 160
+	// length of the ticks of a ruler, at each cell and at each grid line
+	rulerTick     = 2
+	rulerGridTick = 5
+
+	labelBgR = 0
+	labelBgG = 0
+	labelBgB = 0
+	labelBgA = 160
+)
+
+// The grid sizes Shift + I cycles through.
+var gridSizes = []int{5, 10, 20, 50}
+
+// Handles a just pressed key.
+// I toggles the overlay and Shift + I cycles the grid size.
+func (g *physGame) HandleOverlayKey(
+	key ebiten.Key,
+) {
+	if ebiten.KeyI != key {
+		return
+	}
+
+	if !ebiten.IsKeyPressed(ebiten.KeyShift) {
+		g.Overlay = !g.Overlay
+		return
+	}
+
+	for i := 0; i < len(gridSizes); i++ {
+		if gridSizes[i] > g.GridSize {
+			g.GridSize = gridSizes[i]
+			return
+		}
+	}
+	g.GridSize = gridSizes[0]
+}
+
+// Draws text on a dark box, so it can be read above any dots.
+func drawLabel(
+	screen *ebiten.Image,
+	x, y   int,
+	text   string,
+) {
+	vector.DrawFilledRect(screen,
+	                      float32(x),
+	                      float32(y),
+	                      float32(ui.DrawnTextLen(text, uiFontSpacing) + 2),
+	                      float32(ui.DrawnTextH(text) + 2),
+	                      color.RGBA{labelBgR, labelBgG, labelBgB, labelBgA},
+	                      false)
+	ui.DrawText(screen, x + 1, y + 1, text, uiFontSpacing)
+}
+
+// What the status shows of the selection, if anything.
+func (g physGame) selectionStatus(
+) string {
+	var s = g.Selection
+
+	if g.Stroke.Active && extra.Select == extra.Tool(g.Toolbox.Cursor) &&
+	   !g.Stroke.Pasted {
+		s = g.Stroke.Selection()
+	}
+	if !s.Active {
+		return ""
+	}
+
+	return fmt.Sprintf("selection %v x %v at %v, %v",
+	                   s.X2 - s.X1 + 1,
+	                   s.Y2 - s.Y1 + 1,
+	                   s.X1,
+	                   s.Y1)
+}
+
+func (g physGame) drawOverlay(
+	screen *ebiten.Image,
+) {
+	var (
+		grid   = color.RGBA{gridR, gridG, gridB, gridA}
+		ruler  = color.RGBA{rulerR, rulerG, rulerB, rulerA}
+		scale  = float32(g.WorldScale)
+		left   = float32(g.WorldX)
+		top    = float32(g.WorldY)
+		right  = float32(g.WorldX + g.World.W * g.WorldScale)
+		bottom = float32(g.WorldY + g.World.H * g.WorldScale)
+	)
+
+	for x := 0; x <= g.World.W; x++ {
+		sx := left + float32(x) * scale
+		tick := float32(rulerTick)
+
+		if x % g.GridSize == 0 {
+			tick = rulerGridTick
+			vector.StrokeLine(screen, sx, top, sx, bottom, 1, grid, false)
+		}
+		vector.StrokeLine(screen, sx, top, sx, top + tick, 1, ruler, false)
+	}
+
+	for y := 0; y <= g.World.H; y++ {
+		sy := top + float32(y) * scale
+		tick := float32(rulerTick)
+
+		if y % g.GridSize == 0 {
+			tick = rulerGridTick
+			vector.StrokeLine(screen, left, sy, right, sy, 1, grid, false)
+		}
+		vector.StrokeLine(screen, right - tick, sy, right, sy, 1, ruler, false)
+	}
+
+	for x := g.GridSize; x < g.World.W; x += g.GridSize {
+		drawLabel(screen,
+		          g.WorldX + x * g.WorldScale + 1,
+		          g.WorldY + rulerGridTick,
+		          strconv.Itoa(x))
+	}
+
+	for y := g.GridSize; y < g.World.H; y += g.GridSize {
+		text := strconv.Itoa(y)
+		drawLabel(screen,
+		          int(right) - rulerGridTick - 2 -
+		          ui.DrawnTextLen(text, uiFontSpacing),
+		          g.WorldY + y * g.WorldScale + 1,
+		          text)
+	}
+
+	status := ""
+	if x, y := g.HoverPos(); g.InWorldBounds(x, y) {
+		status = fmt.Sprintf("x %v, y %v", x, y)
+	}
+	if sel := g.selectionStatus(); sel != "" {
+		if status != "" {
+			status += ", "
+		}
+		status += sel
+	}
+	if status == "" {
+		return
+	}
+
+	drawLabel(screen,
+	          int(right) - ui.DrawnTextLen(status, uiFontSpacing) - 4,
+	          int(bottom) - ui.DrawnTextH(status) - 4,
+	          status)
+}
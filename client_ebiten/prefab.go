@@ -0,0 +1,537 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image/color"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/core/mat"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// A prefab file is little endian and looks like this:
+//
+//	magic       [8]byte "HAWPSPF\x00"
+//	version     uint16
+//	mat count   uint16
+//	mat names   per mat: uint8 length, then the name
+//	name        uint16 length, then the name
+//	w, h        uint16 each
+//	dots        w * h prefabDot, indexed by x * h + y
+//
+// Older versions lack parts of the dots, which are filled with defaults.
+// The mat names are what keeps old prefabs usable,
+// when mats are added or reordered.
+// Dots are mapped to the mat of the same name on load,
+// and mats that don't exist anymore become None.
+// Mats beyond the names of the file and unknown states, emitters or locks
+// make the file broken, and it is not loaded.
+const (
+	prefabExt     = ".hawpsp"
+	prefabMagic   = "HAWPSPF\x00"
+	prefabVersion = 4
+	prefabMaxDots = 2048 * 2048
+	// how many numbered names are tried, before saving gives up
+	prefabMaxTries = 1000
+)
+
+type prefab struct {
+	Name string
+	Clip clipboard
+}
+
+// Each dot is a prefabDot, followed by the parts added by later versions.
+type prefabDot struct {
+	Dot     uint8
+	State   uint8
+	Spawner uint8
+	SpwnMat uint8
+	Dissol  float64
+	Oxid    float64
+	Thermo  float64
+	Weight  float64
+}
+
+// since version 2
+type prefabDotSpawner struct {
+	SpwnBurst    int32
+	SpwnChance   uint8
+	SpwnInterval int32
+	SpwnThermo   float64
+}
+
+// since version 3
+type prefabDotEmitter struct {
+	Emitter   uint8
+	EmtFixed  uint8
+	EmtMat    uint8
+	EmtThermo float64
+}
+
+// since version 4
+type prefabDotLock struct {
+	Lock uint8
+}
+
+// Where user files, like prefabs, are kept.
+func userDir(
+) (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, AppName), nil
+}
+
+func stdPrefabDir(
+) string {
+	dir, err := userDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "prefabs")
+}
+
+func boolToByte(
+	b bool,
+) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func writePrefab(
+	w io.Writer,
+	p prefab,
+) error {
+	var (
+		bw  = bufio.NewWriter(w)
+		le  = binary.LittleEndian
+		err error
+	)
+
+	write := func(data any) {
+		if err == nil {
+			err = binary.Write(bw, le, data)
+		}
+	}
+
+	write([]byte(prefabMagic))
+	write(uint16(prefabVersion))
+	write(uint16(mat.MatCount))
+	for i := mat.Mat(0); i < mat.Mat(mat.MatCount); i++ {
+		write(uint8(len(mat.Name(i))))
+		write([]byte(mat.Name(i)))
+	}
+	write(uint16(len(p.Name)))
+	write([]byte(p.Name))
+	write(uint16(p.Clip.W))
+	write(uint16(p.Clip.H))
+
+	for i := 0; i < len(p.Clip.Cells); i++ {
+		c := p.Clip.Cells[i]
+		d := prefabDot{
+			Dot:     uint8(c.Dot),
+			State:   uint8(c.State),
+			Spawner: boolToByte(c.Spawner),
+			SpwnMat: uint8(c.SpwnMat),
+			Dissol:  c.Dissol,
+			Oxid:    c.Oxid,
+			Thermo:  c.Thermo,
+			Weight:  c.Weight,
+		}
+		write(d)
+		write(prefabDotSpawner{
+			SpwnBurst:    int32(c.SpwnBurst),
+			SpwnChance:   uint8(c.SpwnChance),
+			SpwnInterval: int32(c.SpwnInterval),
+			SpwnThermo:   c.SpwnThermo,
+		})
+		write(prefabDotEmitter{
+			Emitter:   uint8(c.Emitter),
+			EmtFixed:  boolToByte(c.EmtFixed),
+			EmtMat:    uint8(c.EmtMat),
+			EmtThermo: c.EmtThermo,
+		})
+		write(prefabDotLock{
+			Lock: uint8(c.Lock),
+		})
+	}
+
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func readPrefab(
+	r io.Reader,
+) (prefab, error) {
+	var (
+		br      = bufio.NewReader(r)
+		d       prefabDot
+		de      prefabDotEmitter
+		dl      prefabDotLock
+		ds      prefabDotSpawner
+		fileMat []mat.Mat
+		le      = binary.LittleEndian
+		magic   = make([]byte, len(prefabMagic))
+		matN    uint16
+		nameLen uint16
+		ret     prefab
+		version uint16
+		w, h    uint16
+		err     error
+	)
+
+	read := func(data any) {
+		if err == nil {
+			err = binary.Read(br, le, data)
+		}
+	}
+
+	// maps a mat of the file to the current mats
+	toMat := func(m uint8) mat.Mat {
+		if err == nil && int(m) >= len(fileMat) {
+			err = fmt.Errorf("unknown mat %v", m)
+		}
+		if err != nil {
+			return mat.None
+		}
+		return fileMat[m]
+	}
+
+	read(magic)
+	if err == nil && string(magic) != prefabMagic {
+		return ret, errors.New("not a prefab file")
+	}
+
+	read(&version)
+	if err == nil && (version < 1 || version > prefabVersion) {
+		return ret, fmt.Errorf("unsupported prefab version %v", version)
+	}
+
+	read(&matN)
+	for i := 0; i < int(matN) && err == nil; i++ {
+		var nLen uint8
+
+		read(&nLen)
+		name := make([]byte, nLen)
+		read(name)
+
+		m := mat.None
+		for j := mat.Mat(0); j < mat.Mat(mat.MatCount); j++ {
+			if mat.Name(j) == string(name) {
+				m = j
+				break
+			}
+		}
+		fileMat = append(fileMat, m)
+	}
+
+	read(&nameLen)
+	name := make([]byte, nameLen)
+	read(name)
+	ret.Name = string(name)
+
+	read(&w)
+	read(&h)
+	if err != nil {
+		return ret, err
+	}
+	if int(w) * int(h) > prefabMaxDots {
+		return ret, fmt.Errorf("prefab of %vx%v is too large", w, h)
+	}
+
+	ret.Clip = clipboard{
+		W:     int(w),
+		H:     int(h),
+		Cells: make([]cellState, int(w) * int(h)),
+	}
+
+	for i := 0; i < len(ret.Clip.Cells) && err == nil; i++ {
+		read(&d)
+
+		ds = prefabDotSpawner{
+			SpwnChance:   100,
+			SpwnInterval: 1,
+			SpwnThermo:   d.Thermo,
+		}
+		if version >= 2 {
+			read(&ds)
+		}
+
+		de = prefabDotEmitter{}
+		if version >= 3 {
+			read(&de)
+		}
+
+		dl = prefabDotLock{}
+		if version >= 4 {
+			read(&dl)
+		}
+
+		if err == nil && int(de.Emitter) >= int(core.EmitterCount) {
+			return ret, fmt.Errorf("unknown emitter %v", de.Emitter)
+		}
+		if err == nil && int(dl.Lock) >= int(core.LockCount) {
+			return ret, fmt.Errorf("unknown lock %v", dl.Lock)
+		}
+		if err == nil && int(d.State) >= int(mat.StateCount) {
+			return ret, fmt.Errorf("unknown state %v", d.State)
+		}
+
+		ret.Clip.Cells[i] = cellState{
+			Dissol:       d.Dissol,
+			Dot:          toMat(d.Dot),
+			Emitter:      core.Emitter(de.Emitter),
+			EmtFixed:     de.EmtFixed != 0,
+			EmtMat:       toMat(de.EmtMat),
+			EmtThermo:    de.EmtThermo,
+			Lock:         core.Lock(dl.Lock),
+			Oxid:         d.Oxid,
+			Spawner:      d.Spawner != 0,
+			SpwnBurst:    int(ds.SpwnBurst),
+			SpwnChance:   int(ds.SpwnChance),
+			SpwnInterval: int(ds.SpwnInterval),
+			SpwnMat:      toMat(d.SpwnMat),
+			SpwnThermo:   ds.SpwnThermo,
+			State:        mat.State(d.State),
+			Thermo:       d.Thermo,
+			Weight:       d.Weight,
+		}
+	}
+
+	return ret, err
+}
+
+// Loads every prefab of the directory, sorted by file name.
+// A missing directory just means there are no prefabs yet,
+// and broken files are skipped with a warning.
+func loadPrefabs(
+	dir string,
+) []prefab {
+	var ret []prefab
+
+	if dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Could not read prefabs: %v\n", err)
+		}
+		return nil
+	}
+
+	sort.Slice(entries, func(a, b int) bool {
+		return entries[a].Name() < entries[b].Name()
+	})
+
+	for i := 0; i < len(entries); i++ {
+		if entries[i].IsDir() ||
+		   !strings.HasSuffix(entries[i].Name(), prefabExt) {
+			continue
+		}
+
+		fpath := filepath.Join(dir, entries[i].Name())
+		f, err := os.Open(fpath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not open prefab: %v\n", err)
+			continue
+		}
+
+		p, err := readPrefab(f)
+		f.Close()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not load prefab \"%v\": %v\n",
+			            fpath, err)
+			continue
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// Makes a file name of the prefab name,
+// keeping letters and digits, and replacing everything else with _.
+func prefabFileName(
+	name string,
+) string {
+	var ret = []rune(name)
+
+	for i := 0; i < len(ret); i++ {
+		if !unicode.IsLetter(ret[i]) && !unicode.IsDigit(ret[i]) &&
+		   '-' != ret[i] {
+			ret[i] = '_'
+		}
+	}
+	if len(ret) == 0 {
+		return "prefab"
+	}
+
+	return string(ret)
+}
+
+// Creates a new file in the directory, named base with ext.
+// If that name is taken, a number is appended, so no file is overwritten.
+func createUnique(
+	dir  string,
+	base string,
+	ext  string,
+) (*os.File, error) {
+	for i := 1; i <= prefabMaxTries; i++ {
+		name := base + ext
+		if i > 1 {
+			name = fmt.Sprintf("%v_%v%v", base, i, ext)
+		}
+
+		f, err := os.OpenFile(filepath.Join(dir, name),
+		                      os.O_WRONLY | os.O_CREATE | os.O_EXCL,
+		                      0644)
+		if err == nil {
+			return f, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("all names for \"%v\" are taken", base)
+}
+
+// Copies the selection and asks for its name,
+// after which it is saved as a new prefab.
+func (g *physGame) NamePrefab(
+) {
+	if !g.Selection.Active {
+		return
+	}
+
+	clip := g.Clipboard
+	g.CopySelection(false)
+	p := prefab{Clip: g.Clipboard}
+	g.Clipboard = clip
+
+	g.StartTextInput("prefab name", func(name string) {
+		p.Name = name
+		err := g.SavePrefab(p)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not save prefab: %v\n", err)
+		}
+	})
+}
+
+// Saves the prefab in a new file of the prefab directory.
+func (g *physGame) SavePrefab(
+	p prefab,
+) error {
+	if g.PrefabDir == "" {
+		return errors.New("no prefab directory known")
+	}
+
+	err := os.MkdirAll(g.PrefabDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := createUnique(g.PrefabDir, prefabFileName(p.Name), prefabExt)
+	if err != nil {
+		return err
+	}
+
+	err = writePrefab(f, p)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	g.Prefabs = append(g.Prefabs, p)
+	g.PrefabImgs = append(g.PrefabImgs, genPrefabImage(p))
+	g.UpdateMatbox()
+
+	return nil
+}
+
+// Scales the prefab down to the size of a tile, keeping its aspect ratio.
+func genPrefabImage(
+	p prefab,
+) *ebiten.Image {
+	const size = pngSize * pngScale
+
+	var (
+		ret   = ebiten.NewImage(size, size)
+		scale = float64(max(p.Clip.W, p.Clip.H)) / float64(size)
+	)
+
+	ret.Fill(color.RGBA{uiMatBgR, uiMatBgG, uiMatBgB, uiMatBgA})
+
+	if scale < 1 {
+		scale = 1
+	}
+
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			cx := int(float64(x) * scale)
+			cy := int(float64(y) * scale)
+			if cx >= p.Clip.W || cy >= p.Clip.H {
+				continue
+			}
+
+			c := p.Clip.Cells[cx * p.Clip.H + cy]
+			if c.Spawner {
+				ret.Set(x, y, color.RGBA{spawnerR, spawnerG, spawnerB, 255})
+			} else if mat.None != c.Dot {
+				ret.Set(x, y, color.RGBA{mat.R(c.Dot),
+				                         mat.G(c.Dot),
+				                         mat.B(c.Dot),
+				                         255})
+			}
+		}
+	}
+
+	return ret
+}
+
+func genPrefabImages(
+	prefabs []prefab,
+) []*ebiten.Image {
+	var ret []*ebiten.Image
+
+	for i := 0; i < len(prefabs); i++ {
+		ret = append(ret, genPrefabImage(prefabs[i]))
+	}
+
+	return ret
+}
+
+// The prefab selected in the Matbox, if any.
+func (g physGame) CurPrefab(
+) *prefab {
+	if g.Matbox.Cursor < 0 || g.Matbox.Cursor >= len(g.Prefabs) {
+		return nil
+	}
+
+	return &g.Prefabs[g.Matbox.Cursor]
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+)
+
+// A 2x1 prefab, saved as the current version.
+func testPrefabFile(
+	t *testing.T,
+) []byte {
+	var (
+		buf bytes.Buffer
+		p   = prefab{
+			Name: "test",
+			Clip: clipboard{
+				W:     2,
+				H:     1,
+				Cells: make([]cellState, 2),
+			},
+		}
+	)
+
+	t.Helper()
+
+	p.Clip.Cells[0].Dot = firstRealMat
+	p.Clip.Cells[0].Thermo = 300
+	p.Clip.Cells[1].Thermo = 300
+
+	if err := writePrefab(&buf, p); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// Where the dots start, which is the size of a file without dots.
+func testPrefabDots(
+	t *testing.T,
+) int {
+	var buf bytes.Buffer
+
+	t.Helper()
+
+	if err := writePrefab(&buf, prefab{Name: "test"}); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Len()
+}
+
+func TestPrefabLoad(
+	t *testing.T,
+) {
+	p, err := readPrefab(bytes.NewReader(testPrefabFile(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p.Name != "test" || p.Clip.W != 2 || p.Clip.H != 1 {
+		t.Errorf("loaded %v of %vx%v, want test of 2x1",
+		         p.Name, p.Clip.W, p.Clip.H)
+	}
+	if firstRealMat != p.Clip.Cells[0].Dot ||
+	   mat.None != p.Clip.Cells[1].Dot {
+		t.Errorf("loaded dots %v and %v, want %v and %v",
+		         p.Clip.Cells[0].Dot, p.Clip.Cells[1].Dot,
+		         firstRealMat, mat.None)
+	}
+}
+
+// Each of these files is broken in one byte, or cut short,
+// and must not be loaded.
+func TestPrefabLoadCorrupt(
+	t *testing.T,
+) {
+	var (
+		dots = testPrefabDots(t)
+		spwn = binary.Size(prefabDot{})
+		emt  = spwn + binary.Size(prefabDotSpawner{})
+		lock = emt + binary.Size(prefabDotEmitter{})
+	)
+
+	corrupt := []struct {
+		name string
+		pos  int
+		val  byte
+	}{
+		{"magic", 0, 'X'},
+		{"version", len(prefabMagic), 0xff},
+		{"mat", dots, 0xff},
+		{"state", dots + 1, 0xff},
+		{"spawner mat", dots + 3, 0xff},
+		{"emitter", dots + emt, 0xff},
+		{"emitter mat", dots + emt + 2, 0xff},
+		{"lock", dots + lock, 0xff},
+	}
+
+	for i := 0; i < len(corrupt); i++ {
+		f := testPrefabFile(t)
+		f[corrupt[i].pos] = corrupt[i].val
+
+		if _, err := readPrefab(bytes.NewReader(f)); err == nil {
+			t.Errorf("loaded a prefab with a broken %v",
+			         corrupt[i].name)
+		}
+	}
+
+	f := testPrefabFile(t)
+	if _, err := readPrefab(bytes.NewReader(f[:len(f) - 1])); err == nil {
+		t.Error("loaded a prefab that is cut short")
+	}
+}
@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+	"github.com/SchokiCoder/hawps/extra"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// A preset is written as one line, like this:
+//
+//	1: Brush, Water, 3, 278.15, all
+//
+// Which is the slot, tool, mat, radius, temperature and brush mode.
+// The temperature is in Kelvin, or in Celsius when followed by a C, like 5C.
+// Presets are always written in Kelvin.
+// Tools without mats leave the mat empty.
+// Only the Brush has a brush mode, so other tools write "all" and ignore it.
+// Slot 0 is the last one, as on the keyboard.
+// Empty lines and lines starting with # are ignored.
+const presetFile = "presets.txt"
+
+const (
+	presetCount = 10
+
+	hotbarBgR = 0
+	hotbarBgG = 0
+	hotbarBgB = 0
+	hotbarBgA = 160
+	// tool images are shrunk by this in the hotbar
+	hotbarScale  = 2
+	hotbarSwatch = 5
+)
+
+var brushModeNames = [brushModeCount]string{
+	brushAll:     "all",
+	brushEmpty:   "empty",
+	brushReplace: "replace",
+}
+
+// A tool with its settings, bound to a number key.
+type preset struct {
+	Tool   extra.Tool
+	Mat    mat.Mat
+	Radius int
+	T      float64
+	Mode   brushMode
+}
+
+func stdPresetPath(
+) string {
+	dir, err := userDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, presetFile)
+}
+
+// Tools whose Matbox shows mats, rather than prefabs or recipes.
+func toolHasMats(
+	t extra.Tool,
+) bool {
+	return isBrushTool(t) || extra.Spawner == t || extra.Drain == t
+}
+
+// The radius of the tool, if it has one.
+func (g *physGame) toolRadius(
+	t extra.Tool,
+) *int {
+	switch t {
+	case extra.Brush: fallthrough
+	case extra.Line: fallthrough
+	case extra.Mixture:
+		return &g.BrushRadius
+	case extra.Eraser:
+		return &g.EraserRadius
+	case extra.Hammer:
+		return &g.HammerRadius
+	case extra.Lock:
+		return &g.LockRadius
+	case extra.Heater: fallthrough
+	case extra.Cooler: fallthrough
+	case extra.Igniter:
+		return &g.ThermoRadius
+	}
+
+	return nil
+}
+
+// The temperature the tool works with, if any.
+func (g *physGame) toolT(
+	t extra.Tool,
+) *float64 {
+	switch {
+	case isBrushTool(t) || extra.Mixture == t:
+		return &g.Temperature
+	case extra.Spawner == t:
+		return &g.SpawnerT
+	case extra.Heater == t || extra.Cooler == t:
+		return &g.ThermoSetT
+	case extra.HeatSource == t:
+		return &g.HeatSourceT
+	case extra.HeatSink == t:
+		return &g.HeatSinkT
+	}
+
+	return nil
+}
+
+// The slot of the number key, if it is one.
+func presetSlot(
+	key ebiten.Key,
+) (int, bool) {
+	if key >= ebiten.KeyDigit1 && key <= ebiten.KeyDigit9 {
+		return int(key - ebiten.KeyDigit1), true
+	}
+	if ebiten.KeyDigit0 == key {
+		return presetCount - 1, true
+	}
+
+	return 0, false
+}
+
+// The number key of the slot, as shown in the hotbar and preset file.
+func slotName(
+	slot int,
+) string {
+	return strconv.Itoa((slot + 1) % presetCount)
+}
+
+func parsePreset(
+	line string,
+) (int, preset, error) {
+	var ret preset
+
+	num, rest, found := strings.Cut(line, ":")
+	if !found {
+		return 0, ret, errors.New("missing \":\" after the slot")
+	}
+
+	slot := -1
+	for i := 0; i < presetCount; i++ {
+		if slotName(i) == strings.TrimSpace(num) {
+			slot = i
+		}
+	}
+	if slot < 0 {
+		return 0, ret, fmt.Errorf("\"%v\" is not a slot", num)
+	}
+
+	fields := strings.Split(rest, ",")
+	if len(fields) != 5 {
+		return 0, ret, fmt.Errorf("has %v instead of 5 fields", len(fields))
+	}
+	for i := 0; i < len(fields); i++ {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	ret.Tool = extra.ToolCount
+	for i := 0; i < extra.ToolCount; i++ {
+		if strings.EqualFold(extra.Tool(i).String(), fields[0]) {
+			ret.Tool = extra.Tool(i)
+		}
+	}
+	if extra.ToolCount == ret.Tool {
+		return 0, ret, fmt.Errorf("\"%v\" is not a tool", fields[0])
+	}
+
+	ret.Mat = mat.None
+	if fields[1] != "" {
+		m, found := matByName(fields[1])
+		if !found {
+			return 0, ret, fmt.Errorf("\"%v\" is not a mat", fields[1])
+		}
+		ret.Mat = m
+	}
+
+	radius, err := strconv.Atoi(fields[2])
+	if err != nil || radius < 0 || radius > maxRadius {
+		return 0, ret, fmt.Errorf("\"%v\" is not a valid radius", fields[2])
+	}
+	ret.Radius = radius
+
+	ret.T, err = parseTemperature(fields[3])
+	if err != nil {
+		return 0, ret, err
+	}
+
+	ret.Mode = brushModeCount
+	for i := brushMode(0); i < brushModeCount; i++ {
+		if brushModeNames[i] == fields[4] {
+			ret.Mode = i
+		}
+	}
+	if brushModeCount == ret.Mode {
+		return 0, ret, fmt.Errorf("\"%v\" is not a brush mode", fields[4])
+	}
+
+	return slot, ret, nil
+}
+
+func (p preset) String(
+) string {
+	name := ""
+	if mat.None != p.Mat {
+		name = mat.Name(p.Mat)
+	}
+
+	return fmt.Sprintf("%v, %v, %v, %v, %v",
+	                   p.Tool,
+	                   name,
+	                   p.Radius,
+	                   strconv.FormatFloat(p.T, 'f', -1, 64),
+	                   brushModeNames[p.Mode])
+}
+
+// A missing file just means there are no presets yet,
+// and broken lines are skipped with a warning.
+func loadPresets(
+	path string,
+) [presetCount]*preset {
+	var ret [presetCount]*preset
+
+	if path == "" {
+		return ret
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Could not read presets: %v\n", err)
+		}
+		return ret
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		slot, p, err := parsePreset(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not load preset \"%v\":%v: %v\n",
+			            path, lineNum, err)
+			continue
+		}
+
+		ret[slot] = &p
+	}
+
+	return ret
+}
+
+// Writes all presets to the preset file, replacing what was there.
+func (g physGame) SavePresets(
+) error {
+	if g.PresetPath == "" {
+		return errors.New("no preset file known")
+	}
+
+	err := os.MkdirAll(filepath.Dir(g.PresetPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(g.PresetPath)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(f)
+	fmt.Fprintln(bw, "# slot: tool, mat, radius, temperature, brush mode")
+	for i := 0; i < presetCount; i++ {
+		if g.Presets[i] != nil {
+			fmt.Fprintf(bw, "%v: %v\n", slotName(i), g.Presets[i])
+		}
+	}
+
+	err = bw.Flush()
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// Stores the current tool and its settings in the slot.
+func (g *physGame) StorePreset(
+	slot int,
+) error {
+	var p = preset{
+		Tool: extra.Tool(g.Toolbox.Cursor),
+		Mat:  mat.None,
+		T:    g.Temperature,
+		Mode: brushAll,
+	}
+
+	if toolHasMats(p.Tool) {
+		p.Mat = g.CurMat()
+	}
+	if r := g.toolRadius(p.Tool); r != nil {
+		p.Radius = *r
+	}
+	if t := g.toolT(p.Tool); t != nil {
+		p.T = *t
+	}
+	if extra.Brush == p.Tool {
+		p.Mode = g.BrushMode
+	}
+
+	g.Presets[slot] = &p
+
+	return g.SavePresets()
+}
+
+// Switches to the tool of the slot and adopts its settings.
+// Empty slots do nothing.
+func (g *physGame) ApplyPreset(
+	slot int,
+) {
+	var p = g.Presets[slot]
+
+	if p == nil {
+		return
+	}
+
+	if t := g.toolT(p.Tool); t == &g.Temperature {
+		g.SetBrushT(p.T)
+	} else if t != nil {
+		*t = p.T
+	}
+	if r := g.toolRadius(p.Tool); r != nil {
+		*r = p.Radius
+	}
+	if extra.Brush == p.Tool {
+		g.BrushMode = p.Mode
+	}
+
+	g.SelectTool(int(p.Tool))
+	if toolHasMats(p.Tool) {
+		// the mats of the Spawner depend on its temperature
+		g.UpdateMatbox()
+		if !g.SelectMat(p.Mat) {
+			g.Matbox.Cursor = 0
+		}
+	}
+}
+
+// Handles a just pressed key.
+// Number keys apply their preset, and with Control store one.
+func (g *physGame) HandlePresetKey(
+	key ebiten.Key,
+) {
+	slot, ok := presetSlot(key)
+	if !ok {
+		return
+	}
+
+	if !ebiten.IsKeyPressed(ebiten.KeyControl) {
+		g.ApplyPreset(slot)
+		return
+	}
+
+	err := g.StorePreset(slot)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not save presets: %v\n", err)
+	}
+}
+
+// Shows the slots along the bottom of the world,
+// each with the tool, the mat and the number key.
+func (g physGame) drawHotbar(
+	screen *ebiten.Image,
+) {
+	const size = pngSize * pngScale / hotbarScale
+
+	var (
+		opt = ebiten.DrawImageOptions{}
+		y   = g.WorldY + g.World.H * g.WorldScale - size - 2
+	)
+
+	for i := 0; i < presetCount; i++ {
+		var (
+			p = g.Presets[i]
+			x = g.WorldX + 2 + i * (size + 2)
+		)
+
+		vector.DrawFilledRect(screen,
+		                      float32(x),
+		                      float32(y),
+		                      float32(size),
+		                      float32(size),
+		                      color.RGBA{hotbarBgR,
+		                                 hotbarBgG,
+		                                 hotbarBgB,
+		                                 hotbarBgA},
+		                      false)
+
+		if p != nil {
+			opt.GeoM.Reset()
+			opt.GeoM.Scale(1.0 / hotbarScale, 1.0 / hotbarScale)
+			opt.GeoM.Translate(float64(x), float64(y))
+			screen.DrawImage(g.Toolbox.Tiles[p.Tool], &opt)
+
+			if mat.None != p.Mat {
+				vector.DrawFilledRect(screen,
+				                      float32(x + size - hotbarSwatch),
+				                      float32(y + size - hotbarSwatch),
+				                      hotbarSwatch,
+				                      hotbarSwatch,
+				                      color.RGBA{mat.R(p.Mat),
+				                                 mat.G(p.Mat),
+				                                 mat.B(p.Mat),
+				                                 255},
+				                      false)
+			}
+		}
+
+		ui.DrawText(screen, x + 1, y + 1, slotName(i), uiFontSpacing)
+	}
+}
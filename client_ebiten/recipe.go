@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image/color"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// A recipe is written as one line, like this:
+//
+//	Thermite: 75 Iron Oxide, 25 Aluminum
+//
+// Each part is a ratio followed by a mat name.
+// The ratios don't need to add up to anything,
+// "3 Sulfur, 1 Coal" is the same as "75 Sulfur, 25 Coal".
+// Empty lines and lines starting with # are ignored.
+const recipeFile = "recipes.txt"
+
+// Recipes that are always there, before those of the recipe file.
+var stdRecipes = []string{
+	"Thermite: 75 Iron Oxide, 25 Aluminum",
+	"Sulfur and Coal: 50 Sulfur, 50 Coal",
+	"Clay and Sand: 50 Clay, 50 Sand",
+}
+
+type recipePart struct {
+	Mat   mat.Mat
+	Ratio int
+}
+
+// A blend of mats, that the Mixture tool paints randomly by ratio.
+type recipe struct {
+	Name  string
+	Parts []recipePart
+}
+
+func stdRecipePath(
+) string {
+	dir, err := userDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, recipeFile)
+}
+
+// Finds the mat of the given name, ignoring case.
+func matByName(
+	name string,
+) (mat.Mat, bool) {
+	for i := firstRealMat; i < mat.Mat(mat.MatCount); i++ {
+		if strings.EqualFold(mat.Name(i), name) {
+			return i, true
+		}
+	}
+
+	return mat.None, false
+}
+
+func parseRecipe(
+	line string,
+) (recipe, error) {
+	var ret recipe
+
+	name, parts, found := strings.Cut(line, ":")
+	if !found {
+		return ret, errors.New("missing \":\" after the name")
+	}
+	ret.Name = strings.TrimSpace(name)
+
+	fields := strings.Split(parts, ",")
+	for i := 0; i < len(fields); i++ {
+		ratio, matName, _ := strings.Cut(strings.TrimSpace(fields[i]), " ")
+
+		n, err := strconv.Atoi(ratio)
+		if err != nil || n <= 0 {
+			return ret, fmt.Errorf("\"%v\" is not a valid ratio", ratio)
+		}
+
+		m, found := matByName(strings.TrimSpace(matName))
+		if !found {
+			return ret, fmt.Errorf("\"%v\" is not a mat", matName)
+		}
+
+		ret.Parts = append(ret.Parts, recipePart{Mat: m, Ratio: n})
+	}
+
+	return ret, nil
+}
+
+func (r recipe) String(
+) string {
+	var parts []string
+
+	for i := 0; i < len(r.Parts); i++ {
+		parts = append(parts, fmt.Sprintf("%v %v",
+		                                  r.Parts[i].Ratio,
+		                                  mat.Name(r.Parts[i].Mat)))
+	}
+
+	return r.Name + ": " + strings.Join(parts, ", ")
+}
+
+// Picks one of the mats randomly, according to their ratios.
+func (r recipe) Pick(
+) mat.Mat {
+	total := 0
+	for i := 0; i < len(r.Parts); i++ {
+		total += r.Parts[i].Ratio
+	}
+	if total == 0 {
+		return mat.None
+	}
+
+	n := rand.Intn(total)
+	for i := 0; i < len(r.Parts); i++ {
+		if n < r.Parts[i].Ratio {
+			return r.Parts[i].Mat
+		}
+		n -= r.Parts[i].Ratio
+	}
+
+	return mat.None
+}
+
+// Loads the standard recipes, followed by those of the file.
+// A missing file just means there are no own recipes yet,
+// and broken lines are skipped with a warning.
+func loadRecipes(
+	path string,
+) []recipe {
+	var ret []recipe
+
+	for i := 0; i < len(stdRecipes); i++ {
+		r, err := parseRecipe(stdRecipes[i])
+		if err != nil {
+			panic(err)
+		}
+		ret = append(ret, r)
+	}
+
+	if path == "" {
+		return ret
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Could not read recipes: %v\n", err)
+		}
+		return ret
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		r, err := parseRecipe(line)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not load recipe \"%v\":%v: %v\n",
+			            path, lineNum, err)
+			continue
+		}
+
+		ret = append(ret, r)
+	}
+
+	return ret
+}
+
+// Adds the recipe to the Matbox and appends it to the recipe file.
+func (g *physGame) SaveRecipe(
+	r recipe,
+) error {
+	if g.RecipePath == "" {
+		return errors.New("no recipe file known")
+	}
+
+	err := os.MkdirAll(filepath.Dir(g.RecipePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(g.RecipePath,
+	                      os.O_APPEND | os.O_CREATE | os.O_WRONLY,
+	                      0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(f, r.String())
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	g.Recipes = append(g.Recipes, r)
+	g.RecipeImgs = append(g.RecipeImgs, genRecipeImage(r))
+	g.UpdateMatbox()
+
+	return nil
+}
+
+// Saves the ratio of the mats in the selection as a new recipe,
+// named after the current time.
+func (g *physGame) SaveSelectionRecipe(
+) error {
+	var (
+		counts = make([]int, mat.MatCount)
+		r      = recipe{
+			Name: "mixture " + time.Now().Format("2006-01-02 15:04:05"),
+		}
+		s      = g.Selection
+	)
+
+	if !s.Active {
+		return nil
+	}
+
+	for x := s.X1; x <= s.X2; x++ {
+		for y := s.Y1; y <= s.Y2; y++ {
+			counts[g.World.Dot[x][y]]++
+		}
+	}
+
+	for i := firstRealMat; i < mat.Mat(mat.MatCount); i++ {
+		if counts[i] > 0 {
+			r.Parts = append(r.Parts, recipePart{Mat: i, Ratio: counts[i]})
+		}
+	}
+	if len(r.Parts) == 0 {
+		return errors.New("the selection is empty")
+	}
+
+	return g.SaveRecipe(r)
+}
+
+// Shows a sample of the mixture, the same for every run.
+func genRecipeImage(
+	r recipe,
+) *ebiten.Image {
+	const size = pngSize * pngScale
+
+	var (
+		ret   = ebiten.NewImage(size, size)
+		total int
+		rng   = rand.New(rand.NewSource(1))
+	)
+
+	for i := 0; i < len(r.Parts); i++ {
+		total += r.Parts[i].Ratio
+	}
+
+	ret.Fill(color.RGBA{uiMatBgR, uiMatBgG, uiMatBgB, uiMatBgA})
+
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			n := rng.Intn(total)
+			m := mat.None
+
+			for i := 0; i < len(r.Parts); i++ {
+				if n < r.Parts[i].Ratio {
+					m = r.Parts[i].Mat
+					break
+				}
+				n -= r.Parts[i].Ratio
+			}
+
+			ret.Set(x, y, color.RGBA{mat.R(m), mat.G(m), mat.B(m), 255})
+		}
+	}
+
+	return ret
+}
+
+func genRecipeImages(
+	recipes []recipe,
+) []*ebiten.Image {
+	var ret []*ebiten.Image
+
+	for i := 0; i < len(recipes); i++ {
+		ret = append(ret, genRecipeImage(recipes[i]))
+	}
+
+	return ret
+}
+
+// The recipe selected in the Matbox, if any.
+func (g physGame) CurRecipe(
+) *recipe {
+	if g.Matbox.Cursor < 0 || g.Matbox.Cursor >= len(g.Recipes) {
+		return nil
+	}
+
+	return &g.Recipes[g.Matbox.Cursor]
+}
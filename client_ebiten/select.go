@@ -0,0 +1,278 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/core/mat"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Dots of a rectangle of the world, indexed by x * H + y.
+type clipboard struct {
+	W, H  int
+	Cells []cellState
+}
+
+// A rectangle of the world, where X1 <= X2 and Y1 <= Y2.
+type selection struct {
+	Active bool
+	X1, Y1 int
+	X2, Y2 int
+}
+
+// Rotates by 90 degrees clockwise.
+func (c *clipboard) Rotate(
+) {
+	var cells = make([]cellState, len(c.Cells))
+
+	for x := 0; x < c.W; x++ {
+		for y := 0; y < c.H; y++ {
+			cells[(c.H - 1 - y) * c.W + x] = c.Cells[x * c.H + y]
+		}
+	}
+
+	c.W, c.H = c.H, c.W
+	c.Cells = cells
+}
+
+func (c *clipboard) FlipH(
+) {
+	for x := 0; x < c.W / 2; x++ {
+		for y := 0; y < c.H; y++ {
+			a := x * c.H + y
+			b := (c.W - 1 - x) * c.H + y
+			c.Cells[a], c.Cells[b] = c.Cells[b], c.Cells[a]
+		}
+	}
+}
+
+func (c *clipboard) FlipV(
+) {
+	for x := 0; x < c.W; x++ {
+		for y := 0; y < c.H / 2; y++ {
+			a := x * c.H + y
+			b := x * c.H + (c.H - 1 - y)
+			c.Cells[a], c.Cells[b] = c.Cells[b], c.Cells[a]
+		}
+	}
+}
+
+// Selects the rectangle dragged by the stroke.
+func (s strokeState) Selection(
+) selection {
+	return selection{
+		Active: true,
+		X1:     min(s.StartX, s.X),
+		Y1:     min(s.StartY, s.Y),
+		X2:     max(s.StartX, s.X),
+		Y2:     max(s.StartY, s.Y),
+	}
+}
+
+// Copies the selected dots into the clipboard,
+// and if cut is set, erases them in an undoable way.
+func (g *physGame) CopySelection(
+	cut bool,
+) {
+	var s = g.Selection
+
+	if !s.Active {
+		return
+	}
+
+	g.Clipboard = clipboard{
+		W:     s.X2 - s.X1 + 1,
+		H:     s.Y2 - s.Y1 + 1,
+	}
+	g.Clipboard.Cells = make([]cellState, g.Clipboard.W * g.Clipboard.H)
+
+	for x := s.X1; x <= s.X2; x++ {
+		for y := s.Y1; y <= s.Y2; y++ {
+			g.Clipboard.Cells[(x - s.X1) * g.Clipboard.H + (y - s.Y1)] =
+				getCell(&g.World, x, y)
+		}
+	}
+
+	if !cut {
+		return
+	}
+
+	g.CancelStroke()
+	g.History.Begin(&g.World)
+	for x := s.X1; x <= s.X2; x++ {
+		for y := s.Y1; y <= s.Y2; y++ {
+			g.History.Touch(&g.World, x, y)
+			g.World.UseEraser(x, y, 0)
+		}
+	}
+	g.History.End(&g.World)
+}
+
+// Where the given clipboard would be placed,
+// if pasted with its center at x, y.
+func (c clipboard) Origin(
+	x, y int,
+) (int, int) {
+	return x - c.W / 2, y - c.H / 2
+}
+
+// Places the dots centered at wX, wY, and mirrored.
+// Dots that don't fit into the world or would replace locked ones are dropped.
+// This is meant to be used during a stroke, which records it for undo.
+func (g *physGame) Place(
+	c      clipboard,
+	wX, wY int,
+) {
+	oX, oY := c.Origin(wX, wY)
+
+	for x := 0; x < c.W; x++ {
+		for y := 0; y < c.H; y++ {
+			cell := c.Cells[x * c.H + y]
+
+			g.mirrorCells(oX + x, oY + y, func(px, py int) {
+				if !g.InWorldBounds(px, py) ||
+				   core.LockNone != g.World.Lock[px][py] {
+					return
+				}
+
+				g.History.Touch(&g.World, px, py)
+				cell.Put(&g.World, px, py)
+			})
+		}
+	}
+}
+
+// Places the clipboard centered at wX, wY, which then becomes the selection.
+func (g *physGame) Paste(
+	wX, wY int,
+) {
+	oX, oY := g.Clipboard.Origin(wX, wY)
+
+	g.Place(g.Clipboard, wX, wY)
+
+	g.Selection = selection{
+		Active: true,
+		X1:     max(oX, 0),
+		Y1:     max(oY, 0),
+		X2:     min(oX + g.Clipboard.W - 1, g.World.W - 1),
+		Y2:     min(oY + g.Clipboard.H - 1, g.World.H - 1),
+	}
+	g.Pasting = false
+}
+
+func (g physGame) InWorldBounds(
+	wX, wY int,
+) bool {
+	return wX >= 0 && wX < g.World.W && wY >= 0 && wY < g.World.H
+}
+
+// Handles a just pressed key, while the Select tool is used.
+func (g *physGame) HandleSelectKey(
+	key ebiten.Key,
+) {
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl)
+
+	switch key {
+	case ebiten.KeyC:
+		if ctrl {
+			g.CopySelection(false)
+		}
+
+	case ebiten.KeyX:
+		if ctrl {
+			g.CopySelection(true)
+		} else {
+			g.Clipboard.FlipH()
+		}
+
+	case ebiten.KeyV:
+		if ctrl && len(g.Clipboard.Cells) > 0 {
+			g.Pasting = !g.Pasting
+		}
+
+	case ebiten.KeyM:
+		if ctrl {
+			err := g.SaveSelectionRecipe()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not save recipe: %v\n", err)
+			}
+		}
+
+	case ebiten.KeyR:
+		g.Clipboard.Rotate()
+
+	case ebiten.KeyS:
+		if ctrl {
+			g.NamePrefab()
+		}
+
+	case ebiten.KeyY:
+		if !ctrl {
+			g.Clipboard.FlipV()
+		}
+	}
+}
+
+// Draws the ghost of what placing the dots at the hover position would do.
+func (g physGame) drawGhost(
+	clip      clipboard,
+	drawHover func(x, y int),
+) {
+	oX, oY := clip.Origin(g.HoverPos())
+
+	for x := 0; x < clip.W; x++ {
+		for y := 0; y < clip.H; y++ {
+			c := clip.Cells[x * clip.H + y]
+
+			if c.Spawner {
+				g.ToolImg.Set(oX + x, oY + y,
+					color.RGBA{
+						spawnerR,
+						spawnerG,
+						spawnerB,
+						spawnerA})
+			} else if mat.None != c.Dot {
+				g.ToolImg.Set(oX + x, oY + y,
+					color.NRGBA{
+						mat.R(c.Dot),
+						mat.G(c.Dot),
+						mat.B(c.Dot),
+						toolHoverA})
+			}
+		}
+	}
+
+	rectCells(oX, oY, oX + clip.W - 1, oY + clip.H - 1, false, drawHover)
+}
+
+// Draws the ghost of the clipboard while pasting,
+// and otherwise the selection or the rectangle currently being dragged.
+func (g physGame) drawSelect(
+	drawHover func(x, y int),
+) {
+	if g.Pasting {
+		g.drawGhost(g.Clipboard, drawHover)
+		return
+	}
+
+	if g.Stroke.Active {
+		s := g.Stroke.Selection()
+		rectCells(s.X1, s.Y1, s.X2, s.Y2, false, drawHover)
+		return
+	}
+
+	if g.Selection.Active {
+		rectCells(g.Selection.X1, g.Selection.Y1,
+		          g.Selection.X2, g.Selection.Y2,
+		          false,
+		          drawHover)
+	}
+	drawHover(g.HoverPos())
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"math"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/extra"
+)
+
+// The cells visited here match what the core draws,
+// so that the preview shows exactly what a stroke will place.
+
+// What is known about the press, drag and release of a tool.
+// X and Y are where the tool was last used.
+type strokeState struct {
+	Active  bool
+	// cells strokeArea visited during this segment, by cell index
+	Covered []bool
+	// the stroke placed the clipboard, rather than selecting
+	Pasted  bool
+	StartX  int
+	StartY  int
+	X       int
+	Y       int
+}
+
+func isShapeTool(
+	t extra.Tool,
+) bool {
+	switch t {
+	case extra.Line: fallthrough
+	case extra.Rectangle: fallthrough
+	case extra.Circle:
+		return true
+	}
+	return false
+}
+
+func circleCells(
+	xC, yC int,
+	radius int,
+	filled bool,
+	visit  func(x, y int),
+) {
+	inner := (radius - 1) * (radius - 1) + (radius - 1)
+	outer := radius * radius + radius
+
+	for x := xC - radius; x <= xC + radius; x++ {
+		for y := yC - radius; y <= yC + radius; y++ {
+			d2 := (x - xC) * (x - xC) + (y - yC) * (y - yC)
+
+			if d2 > outer {
+				continue
+			}
+			if !filled && radius > 0 && d2 <= inner {
+				continue
+			}
+
+			visit(x, y)
+		}
+	}
+}
+
+// Visits every cell of the contiguous area of the same mat as x, y.
+func fillCells(
+	w     *core.World,
+	x, y  int,
+	visit func(x, y int),
+) {
+	var (
+		dirs  = [4][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}}
+		seen  []bool
+		stack [][2]int
+	)
+
+	if x < 0 || x >= w.W || y < 0 || y >= w.H {
+		return
+	}
+
+	target := w.Dot[x][y]
+	seen = make([]bool, w.W * w.H)
+	seen[x * w.H + y] = true
+	stack = append(stack, [2]int{x, y})
+
+	for len(stack) > 0 {
+		c := stack[len(stack) - 1]
+		stack = stack[:len(stack) - 1]
+		visit(c[0], c[1])
+
+		for i := 0; i < len(dirs); i++ {
+			nx := c[0] + dirs[i][0]
+			ny := c[1] + dirs[i][1]
+
+			if nx < 0 || nx >= w.W || ny < 0 || ny >= w.H ||
+			   seen[nx * w.H + ny] || w.Dot[nx][ny] != target {
+				continue
+			}
+
+			seen[nx * w.H + ny] = true
+			stack = append(stack, [2]int{nx, ny})
+		}
+	}
+}
+
+// Bresenham, which is also what the core uses.
+func lineCells(
+	x1, y1 int,
+	x2, y2 int,
+	visit  func(x, y int),
+) {
+	var (
+		dx  = x2 - x1
+		dy  = y2 - y1
+		sx  = 1
+		sy  = 1
+	)
+
+	if dx < 0 {
+		dx = -dx
+		sx = -1
+	}
+	if dy > 0 {
+		dy = -dy
+	} else {
+		sy = -1
+	}
+
+	err := dx + dy
+	for {
+		visit(x1, y1)
+
+		if x1 == x2 && y1 == y2 {
+			break
+		}
+
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+}
+
+func rectCells(
+	x1, y1 int,
+	x2, y2 int,
+	filled bool,
+	visit  func(x, y int),
+) {
+	l, r := min(x1, x2), max(x1, x2)
+	u, d := min(y1, y2), max(y1, y2)
+
+	for x := l; x <= r; x++ {
+		for y := u; y <= d; y++ {
+			if !filled && x != l && x != r && y != u && y != d {
+				continue
+			}
+			visit(x, y)
+		}
+	}
+}
+
+// The radius of a circle dragged from the start to the end of a stroke.
+func (s strokeState) CircleRadius(
+) int {
+	return int(math.Round(math.Hypot(float64(s.X - s.StartX),
+	                                 float64(s.Y - s.StartY))))
+}
+
+// Visits the cells the current shape tool would place, if the stroke ended now.
+func (g physGame) shapeCells(
+	visit func(x, y int),
+) {
+	var (
+		r = g.BrushRadius
+		s = g.Stroke
+	)
+
+	switch extra.Tool(g.Toolbox.Cursor) {
+	case extra.Line:
+		lineCells(s.StartX, s.StartY, s.X, s.Y, func(x, y int) {
+			rectCells(x - r, y - r, x + r, y + r, true, visit)
+		})
+
+	case extra.Rectangle:
+		rectCells(s.StartX, s.StartY, s.X, s.Y, g.ShapeFilled, visit)
+
+	case extra.Circle:
+		circleCells(s.StartX, s.StartY, s.CircleRadius(),
+		            g.ShapeFilled, visit)
+	}
+}
+
+// Applies a shape tool and its mirrored shapes, once its stroke is released.
+func (g *physGame) EndStroke(
+) {
+	var s = g.Stroke
+
+	if !s.Active {
+		return
+	}
+
+	g.shapeCells(func(x, y int) {
+		g.mirrorCells(x, y, func(mx, my int) {
+			g.History.Touch(&g.World, mx, my)
+		})
+	})
+
+	ms := g.mirrors()
+	for i := 0; i < len(ms); i++ {
+		x1, y1 := g.mirrorPos(ms[i], s.StartX, s.StartY)
+		x2, y2 := g.mirrorPos(ms[i], s.X, s.Y)
+
+		switch extra.Tool(g.Toolbox.Cursor) {
+		case extra.Line:
+			g.World.UseLine(g.CurMat(), g.Temperature,
+			                x1, y1, x2, y2,
+			                g.BrushRadius)
+
+		case extra.Rectangle:
+			g.World.UseRect(g.CurMat(), g.Temperature,
+			                x1, y1, x2, y2,
+			                g.ShapeFilled)
+
+		case extra.Circle:
+			g.World.UseCircle(g.CurMat(), g.Temperature,
+			                  x1, y1, s.CircleRadius(),
+			                  g.ShapeFilled)
+		}
+	}
+
+	switch extra.Tool(g.Toolbox.Cursor) {
+	case extra.Select:
+		if !s.Pasted {
+			g.Selection = s.Selection()
+		}
+	}
+
+	g.CancelStroke()
+}
+
+// Ends the stroke without placing a shape.
+// Whatever the stroke already changed is kept and can be undone.
+func (g *physGame) CancelStroke(
+) {
+	var covered = g.Stroke.Covered
+
+	if g.Stroke.Active {
+		g.History.End(&g.World)
+	}
+
+	// kept, so the next stroke doesn't need to allocate it again
+	g.Stroke = strokeState{Covered: covered}
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	spawnerTStep        = 10
+	spawnerTFastStep    = 100
+	spawnerChanceStep   = 10
+	spawnerIntervalMax  = 1000
+	spawnerIntervalFast = 10
+	// how far from stdTemperature a spawner shows fully hot or cold
+	spawnerTColorRange  = 500
+	spawnerMinA         = 48
+)
+
+// Bursts the B key cycles through, 0 being unlimited.
+var spawnerBursts = []int{0, 1, 10, 100, 1000}
+
+// Handles a just pressed key, while the Spawner tool is used.
+// These set what new spawners are placed with.
+func (g *physGame) HandleSpawnerKey(
+	key ebiten.Key,
+) {
+	var (
+		shift  = ebiten.IsKeyPressed(ebiten.KeyShift)
+		tStep  float64 = spawnerTStep
+		iStep  = 1
+	)
+
+	if shift {
+		tStep = spawnerTFastStep
+		iStep = spawnerIntervalFast
+	}
+
+	switch key {
+	case ebiten.KeyBracketLeft:
+		g.SpawnerT = max(g.SpawnerT - tStep, 0)
+		g.RefreshMatbox()
+
+	case ebiten.KeyBracketRight:
+		g.SpawnerT += tStep
+		g.RefreshMatbox()
+
+	case ebiten.KeyComma:
+		g.SpawnerChance = max(g.SpawnerChance - spawnerChanceStep, 0)
+
+	case ebiten.KeyPeriod:
+		g.SpawnerChance = min(g.SpawnerChance + spawnerChanceStep, 100)
+
+	case ebiten.KeySemicolon:
+		g.SpawnerInterval = max(g.SpawnerInterval - iStep, 1)
+
+	case ebiten.KeyApostrophe:
+		g.SpawnerInterval = min(g.SpawnerInterval + iStep,
+		                        spawnerIntervalMax)
+
+	case ebiten.KeyB:
+		for i := 0; i < len(spawnerBursts); i++ {
+			if spawnerBursts[i] == g.SpawnerBurst {
+				g.SpawnerBurst =
+					spawnerBursts[(i + 1) % len(spawnerBursts)]
+				return
+			}
+		}
+		g.SpawnerBurst = spawnerBursts[0]
+	}
+}
+
+// Rebuilds the Matbox while keeping the selected mat, if possible.
+// This is needed when the temperature changes,
+// as for example which mats can be spawned depends on it.
+func (g *physGame) RefreshMatbox(
+) {
+	cur := g.CurMat()
+
+	g.UpdateMatbox()
+	if !g.SelectMat(cur) {
+		g.Matbox.Cursor = 0
+	}
+}
+
+// Spawners are tinted blue when colder and orange when hotter than usual.
+// The less often they spawn, the more transparent they are,
+// and spawners that run out after a burst are drawn checkered.
+func (g physGame) spawnerColor(
+	x, y int,
+) color.Color {
+	var (
+		a    float64
+		d    float64
+		r, gr, b float64
+	)
+
+	d = (g.World.SpwnThermo[x][y] - stdTemperature) / spawnerTColorRange
+	d = max(min(d, 1), -1)
+
+	if d >= 0 {
+		r = spawnerR
+		gr = spawnerG + 128 * d
+		b = spawnerB * (1 - d)
+	} else {
+		r = spawnerR * (1 + d)
+		gr = spawnerG + 128 * -d
+		b = spawnerB
+	}
+
+	a = spawnerA * float64(g.World.SpwnChance[x][y]) / 100
+	a /= float64(max(g.World.SpwnInterval[x][y], 1))
+	a = max(a, spawnerMinA)
+
+	if g.World.SpwnBurst[x][y] > 0 && (x + y) % 2 == 0 {
+		a /= 2
+	}
+
+	return color.NRGBA{uint8(r), uint8(gr), uint8(b), uint8(a)}
+}
+
+// Shows what new spawners are placed with, in the top left of the world.
+func (g physGame) drawSpawnerInfo(
+	screen *ebiten.Image,
+) {
+	burst := "unlimited"
+	if g.SpawnerBurst > 0 {
+		burst = fmt.Sprintf("%v", g.SpawnerBurst)
+	}
+
+	text := fmt.Sprintf("%v, every %v ticks, %v%%, %v",
+	                    g.FormatT(g.SpawnerT),
+	                    g.SpawnerInterval,
+	                    g.SpawnerChance,
+	                    burst)
+
+	ui.DrawText(screen, g.WorldX + 2, g.WorldY + 2, text, uiFontSpacing)
+}
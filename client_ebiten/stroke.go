@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+// Input is only sampled once per Update, so a fast drag would leave gaps.
+// Therefore tools are applied along the whole segment,
+// from where the stroke was last used to where it is now.
+
+// Visits each point of the segment between the last and the given position,
+// and their mirrored points.
+// The last position was already used, so it is skipped, unless it is the only
+// point of the segment, which happens when the pointer is held still.
+func (g physGame) strokeSegment(
+	wX, wY int,
+	visit  func(x, y int),
+) {
+	var (
+		first = true
+		moved = g.Stroke.X != wX || g.Stroke.Y != wY
+	)
+
+	lineCells(g.Stroke.X, g.Stroke.Y, wX, wY, func(x, y int) {
+		if first && moved {
+			first = false
+			return
+		}
+		g.mirrorCells(x, y, visit)
+	})
+}
+
+// Like strokeSegment, but visits every cell within the radius of any point
+// of the segment, unless the segment already visited it.
+// So each cell is visited at most once per segment, which is once per tick.
+// This is needed for tools that accumulate, like the heater,
+// which would otherwise heat the overlap of neighbouring stamps many times,
+// while holding it still keeps heating each tick.
+func (g *physGame) strokeArea(
+	wX, wY int,
+	radius int,
+	visit  func(x, y int),
+) {
+	if len(g.Stroke.Covered) != g.World.W * g.World.H {
+		g.Stroke.Covered = make([]bool, g.World.W * g.World.H)
+	}
+	clear(g.Stroke.Covered)
+
+	g.strokeSegment(wX, wY, func(x, y int) {
+		x1 := max(x - radius, 0)
+		x2 := min(x + radius, g.World.W - 1)
+		y1 := max(y - radius, 0)
+		y2 := min(y + radius, g.World.H - 1)
+
+		for cx := x1; cx <= x2; cx++ {
+			for cy := y1; cy <= y2; cy++ {
+				if g.Stroke.Covered[cx * g.World.H + cy] {
+					continue
+				}
+				g.Stroke.Covered[cx * g.World.H + cy] = true
+				visit(cx, cy)
+			}
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SchokiCoder/hawps/extra"
+)
+
+func newHeaterTestGame(
+	t *testing.T,
+) physGame {
+	var g = newTouchTestGame(t)
+
+	t.Helper()
+
+	g.Toolbox.Cursor = int(extra.Heater)
+	g.UpdateMatbox()
+	g.ThermoRadius = 2
+
+	return g
+}
+
+func checkHeated(
+	t     *testing.T,
+	g     *physGame,
+	x, y  int,
+	t0    float64,
+	times int,
+) {
+	var want = t0 + float64(times) * g.HeaterDelta
+
+	t.Helper()
+
+	if math.Abs(g.World.Thermo[x][y] - want) > 1e-3 {
+		t.Errorf("%v, %v is at %v K, want %v K, heated %v times",
+		         x, y, g.World.Thermo[x][y], want, times)
+	}
+}
+
+// Holding the Heater still keeps heating, once each tick.
+func TestStrokeHeaterHeld(
+	t *testing.T,
+) {
+	var (
+		g  = newHeaterTestGame(t)
+		t0 = g.World.Thermo[10][10]
+	)
+
+	for i := 1; i <= 3; i++ {
+		g.UseTool(10, 10)
+
+		checkHeated(t, &g, 10, 10, t0, i)
+		checkHeated(t, &g, 12, 12, t0, i)
+		checkHeated(t, &g, 13, 10, t0, 0)
+	}
+	g.EndStroke()
+}
+
+// Moving the Heater by a few cells in one tick
+// heats the overlap of the stamps of that tick only once.
+func TestStrokeHeaterMoved(
+	t *testing.T,
+) {
+	var (
+		g  = newHeaterTestGame(t)
+		t0 = g.World.Thermo[10][10]
+	)
+
+	g.UseTool(10, 10)
+	g.UseTool(12, 10)
+	g.EndStroke()
+
+	checkHeated(t, &g, 8, 10, t0, 1)
+	checkHeated(t, &g, 11, 10, t0, 2)
+	checkHeated(t, &g, 14, 10, t0, 1)
+	checkHeated(t, &g, 15, 10, t0, 0)
+}
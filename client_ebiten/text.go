@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	stdText      = "HAWPS"
+	stdTextScale = 2
+	maxTextScale = 16
+	// glyph pixels at least this opaque become dots
+	textAlphaMin = 128
+
+	textInputHint = "Enter takes it, Escape cancels"
+)
+
+// The text of the Text tool, as drawn by the UI font.
+// Each cell is one pixel of the font, which TextScale enlarges on use.
+type textStamp struct {
+	Text  string
+	W, H  int
+	Cells []bool
+}
+
+// Draws the text with the UI font and keeps which pixels it covered.
+// The pixels can only be read back once the game runs,
+// so this is done on demand during Update.
+func newTextStamp(
+	text string,
+) textStamp {
+	var ret = textStamp{Text: text}
+
+	ret.W = ui.DrawnTextLen(text, uiFontSpacing)
+	ret.H = ui.DrawnTextH(text)
+	if 0 == ret.W || 0 == ret.H {
+		return ret
+	}
+
+	img := ebiten.NewImage(ret.W, ret.H)
+	defer img.Deallocate()
+	ui.DrawText(img, 0, 0, text, uiFontSpacing)
+
+	pix := make([]byte, 4 * ret.W * ret.H)
+	img.ReadPixels(pix)
+
+	ret.Cells = make([]bool, ret.W * ret.H)
+	for x := 0; x < ret.W; x++ {
+		for y := 0; y < ret.H; y++ {
+			ret.Cells[x * ret.H + y] = pix[(y * ret.W + x) * 4 + 3] >=
+			                           textAlphaMin
+		}
+	}
+
+	return ret
+}
+
+// Visits the cells the text covers, with its top left at x, y.
+// These may lie outside of the world.
+func (g physGame) textCells(
+	x, y  int,
+	visit func(x, y int),
+) {
+	var s = g.TextStamp
+
+	for tx := 0; tx < s.W; tx++ {
+		for ty := 0; ty < s.H; ty++ {
+			if !s.Cells[tx * s.H + ty] {
+				continue
+			}
+
+			for sx := 0; sx < g.TextScale; sx++ {
+				for sy := 0; sy < g.TextScale; sy++ {
+					visit(x + tx * g.TextScale + sx,
+					      y + ty * g.TextScale + sy)
+				}
+			}
+		}
+	}
+}
+
+// Makes the stamp anew, if the text changed since.
+func (g *physGame) UpdateTextStamp(
+) {
+	if g.TextStamp.Text != g.Text || g.TextStamp.Cells == nil {
+		g.TextStamp = newTextStamp(g.Text)
+	}
+}
+
+// Writes the text into the world,
+// in the selected mat and at the brush temperature.
+func (g *physGame) StampText(
+	x, y int,
+) {
+	var m = g.CurMat()
+
+	g.UpdateTextStamp()
+	g.textCells(x, y, func(tx, ty int) {
+		g.mirrorCells(tx, ty, func(cx, cy int) {
+			if !g.InWorldBounds(cx, cy) {
+				return
+			}
+
+			g.History.Touch(&g.World, cx, cy)
+			g.World.UseBrush(m, g.Temperature, cx, cy, 0)
+		})
+	})
+}
+
+// Starts typing, for which the prompt is shown.
+// Once Enter is pressed, done gets what was typed, unless that is nothing.
+func (g *physGame) StartTextInput(
+	prompt string,
+	done   func(string),
+) {
+	g.TextInput = []rune{}
+	g.TextPrompt = prompt
+	g.TextDone = done
+}
+
+// Handles a just pressed key, while the Text tool is used.
+// Enter starts typing a new text, and Comma and Period change the scale.
+func (g *physGame) HandleTextKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyEnter:
+		g.StartTextInput("text", func(text string) {
+			g.Text = text
+			g.UpdateTextStamp()
+		})
+
+	case ebiten.KeyComma:
+		g.TextScale = max(g.TextScale - 1, 1)
+
+	case ebiten.KeyPeriod:
+		g.TextScale = min(g.TextScale + 1, maxTextScale)
+	}
+}
+
+// Takes typed chars, while typing.
+// Enter hands the input over and Escape drops it.
+func (g *physGame) HandleTextInput(
+	keys []ebiten.Key,
+) {
+	var done = g.TextDone
+
+	g.TextInput = ebiten.AppendInputChars(g.TextInput)
+
+	for i := 0; i < len(keys); i++ {
+		switch keys[i] {
+		case ebiten.KeyBackspace:
+			if len(g.TextInput) > 0 {
+				g.TextInput = g.TextInput[:len(g.TextInput) - 1]
+			}
+
+		case ebiten.KeyEnter: fallthrough
+		case ebiten.KeyNumpadEnter:
+			input := string(g.TextInput)
+			g.TextInput = nil
+			g.TextDone = nil
+			if input != "" {
+				done(input)
+			}
+			return
+
+		case ebiten.KeyEscape:
+			g.TextInput = nil
+			g.TextDone = nil
+			return
+		}
+	}
+}
+
+// Shows the text and its scale, below the temperature of new dots.
+func (g physGame) drawTextInfo(
+	screen *ebiten.Image,
+) {
+	var text = fmt.Sprintf("\"%v\", scale %v", g.Text, g.TextScale)
+
+	ui.DrawText(screen,
+	            g.WorldX + 2,
+	            g.WorldY + 4 + ui.DrawnTextH(text),
+	            text,
+	            uiFontSpacing)
+}
+
+// Shows the prompt and what is typed in the middle of the world.
+func (g physGame) drawTextInput(
+	screen *ebiten.Image,
+) {
+	g.drawDialog(screen,
+	             g.TextPrompt + ": " + string(g.TextInput) + "_",
+	             textInputHint)
+}
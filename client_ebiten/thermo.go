@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+	"github.com/SchokiCoder/hawps/extra"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Temperatures are always kept in Kelvin,
+// and only shown and stepped through in Celsius, if that is wanted.
+
+// Parses a temperature like "293.15", "293.15 K", "20C" or "20 °C" into Kelvin.
+// Without a unit, Kelvin is assumed.
+func parseTemperature(
+	s string,
+) (float64, error) {
+	var (
+		offset float64
+		num    = strings.ToUpper(strings.TrimSpace(s))
+	)
+
+	switch {
+	case strings.HasSuffix(num, "C"):
+		num = strings.TrimSuffix(strings.TrimSuffix(num, "C"), "°")
+		offset = celsiusToKelvin
+
+	case strings.HasSuffix(num, "K"):
+		num = strings.TrimSuffix(num, "K")
+	}
+
+	t, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, fmt.Errorf("\"%v\" is not a temperature", s)
+	}
+
+	t += offset
+	if t < 0 {
+		return 0, fmt.Errorf("%v is below absolute zero", s)
+	}
+
+	return t, nil
+}
+
+func (g physGame) FormatT(
+	t float64,
+) string {
+	if g.Celsius {
+		return fmt.Sprintf("%.0f C", t - celsiusToKelvin)
+	}
+	return fmt.Sprintf("%.0f K", t)
+}
+
+// Tools that place dots at the brush temperature.
+func isBrushTool(
+	t extra.Tool,
+) bool {
+	switch t {
+	case extra.Brush: fallthrough
+	case extra.Line: fallthrough
+	case extra.Rectangle: fallthrough
+	case extra.Circle: fallthrough
+	case extra.Fill: fallthrough
+	case extra.Text:
+		return true
+	}
+	return false
+}
+
+// Sets the temperature of new dots.
+// Since the Matbox of the brush tools shows the state of each mat
+// at that temperature, it is rebuilt.
+func (g *physGame) SetBrushT(
+	t float64,
+) {
+	g.Temperature = t
+
+	if isBrushTool(extra.Tool(g.Toolbox.Cursor)) {
+		g.RefreshMatbox()
+	}
+}
+
+// Makes the mat images anew,
+// if they don't show the temperature of the current tool,
+// which is the brush temperature for tools without one.
+func (g *physGame) UpdateMatImgs(
+) {
+	var t = g.Temperature
+
+	if toolT := g.toolT(extra.Tool(g.Toolbox.Cursor)); toolT != nil {
+		t = *toolT
+	}
+	if t == g.MatImgsT {
+		return
+	}
+
+	imgs, err := genMatImages(g.Assets, t)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load material images: %v\n", err)
+		return
+	}
+	g.MatImgs = imgs
+	g.MatImgsT = t
+}
+
+// Sets the temperature the tool works with.
+// Tools that can also heat or cool by a step switch to the set temperature,
+// since that is what was asked for.
+func (g *physGame) SetToolT(
+	tool extra.Tool,
+	t    float64,
+) {
+	var target = g.toolT(tool)
+
+	switch {
+	case target == nil:
+		return
+
+	case target == &g.Temperature:
+		g.SetBrushT(t)
+		return
+	}
+
+	*target = t
+
+	switch tool {
+	case extra.Spawner:
+		g.RefreshMatbox()
+
+	case extra.Heater: fallthrough
+	case extra.Cooler:
+		g.ThermoSet = true
+
+	case extra.HeatSource: fallthrough
+	case extra.HeatSink:
+		g.HeatFixed = true
+	}
+}
+
+// Handles a just pressed key.
+// Backslash starts typing the temperature of the current tool,
+// in Kelvin, or in Celsius when followed by a C.
+func (g *physGame) HandleTypedTKey(
+	key ebiten.Key,
+) {
+	var tool = extra.Tool(g.Toolbox.Cursor)
+
+	if ebiten.KeyBackslash != key || g.toolT(tool) == nil {
+		return
+	}
+
+	g.StartTextInput("temperature", func(text string) {
+		t, err := parseTemperature(text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not set temperature: %v\n", err)
+			return
+		}
+		g.SetToolT(tool, t)
+	})
+}
+
+// Handles a just pressed key, while a brush tool, the Heater or Cooler is used.
+// [ and ] change the brush temperature,
+// or the strength of the Heater and Cooler,
+// and M switches those to setting an exact temperature instead.
+func (g *physGame) HandleThermoKey(
+	key ebiten.Key,
+) {
+	var (
+		delta float64
+		shift = ebiten.IsKeyPressed(ebiten.KeyShift)
+		tool  = extra.Tool(g.Toolbox.Cursor)
+	)
+
+	switch key {
+	case ebiten.KeyM:
+		if extra.Heater == tool || extra.Cooler == tool {
+			g.ThermoSet = !g.ThermoSet
+		}
+		return
+
+	case ebiten.KeyBracketLeft:
+		delta = -1
+
+	case ebiten.KeyBracketRight:
+		delta = 1
+
+	default:
+		return
+	}
+
+	if (extra.Heater == tool || extra.Cooler == tool) && !g.ThermoSet {
+		if shift {
+			delta *= heatDeltaFast
+		} else {
+			delta *= heatDeltaStep
+		}
+		g.HeaterDelta = max(g.HeaterDelta + delta, heatDeltaStep)
+		return
+	}
+
+	if shift {
+		delta *= spawnerTFastStep
+	} else {
+		delta *= spawnerTStep
+	}
+
+	if isBrushTool(tool) || extra.Mixture == tool {
+		g.SetBrushT(max(g.Temperature + delta, 0))
+	} else {
+		g.ThermoSetT = max(g.ThermoSetT + delta, 0)
+	}
+}
+
+// Shows the temperature related settings of the current tool,
+// in the top left of the world.
+func (g physGame) drawThermoInfo(
+	screen *ebiten.Image,
+) {
+	var (
+		text string
+		tool = extra.Tool(g.Toolbox.Cursor)
+	)
+
+	switch {
+	case isBrushTool(tool) || extra.Mixture == tool:
+		text = "new dots at " + g.FormatT(g.Temperature)
+
+	case extra.Heater != tool && extra.Cooler != tool:
+		return
+
+	case g.ThermoSet:
+		text = "sets to " + g.FormatT(g.ThermoSetT)
+
+	case extra.Heater == tool:
+		text = fmt.Sprintf("heats by %.1f K per tick", g.HeaterDelta)
+
+	default:
+		text = fmt.Sprintf("cools by %.1f K per tick", g.HeaterDelta)
+	}
+
+	ui.DrawText(screen, g.WorldX + 2, g.WorldY + 2, text, uiFontSpacing)
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+const (
+	// pixels two fingers need to move apart, to grow the radius by one
+	touchPinchStep = 12
+)
+
+type touch struct {
+	ID   ebiten.TouchID
+	X, Y int
+}
+
+// Whatever needs to be remembered between ticks, to recognize gestures.
+// Once a second finger touched, no tool is used until all fingers are lifted,
+// so that lifting one finger of a gesture does not paint.
+type touchState struct {
+	Active    bool
+	Gesture   bool
+	PinchAcc  float64
+	PrevDist  float64
+	PrevMidX  int
+	PrevMidY  int
+	ScrollAcc int
+}
+
+// Reuses buf to return the current touches ordered by their ID.
+func pollTouches(
+	buf []touch,
+) []touch {
+	var ids []ebiten.TouchID
+
+	ids = ebiten.AppendTouchIDs(ids)
+	buf = buf[:0]
+
+	for i := 0; i < len(ids); i++ {
+		x, y := ebiten.TouchPosition(ids[i])
+		buf = append(buf, touch{ID: ids[i], X: x, Y: y})
+	}
+
+	sort.Slice(buf, func(a, b int) bool {
+		return buf[a].ID < buf[b].ID
+	})
+
+	return buf
+}
+
+// Takes all touches currently down, which may also be synthetic ones.
+// A single finger acts like the left mouse button.
+// Two fingers pinching over the world change the tool radius,
+// and two fingers dragging over the Matbox scroll it.
+func (g *physGame) HandleTouches(
+	touches []touch,
+) {
+	var (
+		a, b       touch
+		dist       float64
+		midX, midY int
+		steps      int
+	)
+
+	switch len(touches) {
+	case 0:
+		if !g.Touch.Gesture {
+			g.EndStroke()
+		}
+		g.Touch = touchState{}
+
+	case 1:
+		g.Touch.Active = true
+		if g.Touch.Gesture {
+			return
+		}
+
+		g.CursorX = touches[0].X
+		g.CursorY = touches[0].Y
+		g.WCursorShown = false
+		g.HandleClick(touches[0].X, touches[0].Y)
+
+	default:
+		a = touches[0]
+		b = touches[1]
+		dist = math.Hypot(float64(a.X - b.X), float64(a.Y - b.Y))
+		midX = (a.X + b.X) / 2
+		midY = (a.Y + b.Y) / 2
+
+		if !g.Touch.Gesture {
+			g.CancelStroke()
+			g.Touch = touchState{
+				Active:   true,
+				Gesture:  true,
+				PrevDist: dist,
+				PrevMidX: midX,
+				PrevMidY: midY,
+			}
+			return
+		}
+
+		if g.InWorld(midX, midY) {
+			g.Touch.PinchAcc += dist - g.Touch.PrevDist
+			steps = int(g.Touch.PinchAcc / touchPinchStep)
+			if steps != 0 {
+				g.Touch.PinchAcc -= float64(steps * touchPinchStep)
+				g.ChangeRadius(steps)
+			}
+		}
+
+		if g.Matbox.Horizontal() {
+			g.Touch.ScrollAcc += midY - g.Touch.PrevMidY
+		} else {
+			g.Touch.ScrollAcc += midX - g.Touch.PrevMidX
+		}
+		steps = g.Touch.ScrollAcc / (pngSize * pngScale)
+		if steps != 0 {
+			g.Matbox.HandleWheel(midX, midY, steps)
+			g.Touch.ScrollAcc -= steps * (pngSize * pngScale)
+		}
+
+		g.Touch.PrevDist = dist
+		g.Touch.PrevMidX = midX
+		g.Touch.PrevMidY = midY
+	}
+}
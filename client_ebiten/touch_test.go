@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"testing"
+
+	"github.com/SchokiCoder/hawps/core"
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+	"github.com/SchokiCoder/hawps/extra"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// The world is in the top left, at a scale of 2,
+// and the Matbox to the right of it.
+const (
+	testWorldW     = 50
+	testWorldH     = 40
+	testWorldScale = 2
+	testMatboxX    = 200
+	testTile       = pngSize * pngScale
+)
+
+// Makes a game with the Brush and the first mat selected.
+func newTouchTestGame(
+	t *testing.T,
+) physGame {
+	var (
+		g        = newPhysGame()
+		toolImgs []*ebiten.Image
+		matImgs  []*ebiten.Image
+		tools    []int
+	)
+
+	t.Helper()
+
+	for i := 0; i < extra.ToolCount; i++ {
+		toolImgs = append(toolImgs, ebiten.NewImage(testTile, testTile))
+		tools = append(tools, i)
+	}
+	for i := 0; i < mat.MatCount; i++ {
+		matImgs = append(matImgs, ebiten.NewImage(testTile, testTile))
+	}
+
+	g.Toolbox = ui.NewTileSetFromImgs(true,
+	                                  uiTileSetW,
+	                                  testTile * uiTileSetW,
+	                                  testTile,
+	                                  toolImgs)
+	g.Toolbox.Y = 1000
+	g.Toolbox.VisibleTiles = tools
+
+	// one tile wide, so every mat is its own row to scroll through
+	g.Matbox = ui.NewTileSetFromImgs(true,
+	                                 1,
+	                                 testTile,
+	                                 testTile * 4,
+	                                 matImgs)
+	g.Matbox.X = testMatboxX
+	g.MatImgs = matImgs
+	g.MatImgsT = g.Temperature
+
+	g.WorldScale = testWorldScale
+	g.World = core.NewWorld(testWorldW, testWorldH, g.Temperature)
+
+	g.BrushRadius = 0
+	g.Toolbox.Cursor = int(extra.Brush)
+	g.UpdateMatbox()
+	if firstRealMat != mat.Mat(g.Matbox.VisibleTiles[g.Matbox.Cursor]) {
+		t.Fatal("the Brush does not start with the first mat")
+	}
+
+	return g
+}
+
+// The screen position of the center of a world cell.
+func touchAt(
+	id   ebiten.TouchID,
+	x, y int,
+) touch {
+	return touch{
+		ID: id,
+		X:  x * testWorldScale + testWorldScale / 2,
+		Y:  y * testWorldScale + testWorldScale / 2,
+	}
+}
+
+func countDots(
+	w *core.World,
+	m mat.Mat,
+) int {
+	var ret int
+
+	for x := 0; x < w.W; x++ {
+		for y := 0; y < w.H; y++ {
+			if m == w.Dot[x][y] {
+				ret++
+			}
+		}
+	}
+
+	return ret
+}
+
+func TestTouchTap(
+	t *testing.T,
+) {
+	var g = newTouchTestGame(t)
+
+	g.HandleTouches([]touch{touchAt(0, 10, 12)})
+	if !g.Stroke.Active {
+		t.Fatal("a single finger did not start a stroke")
+	}
+	g.HandleTouches(nil)
+
+	if g.Stroke.Active {
+		t.Error("lifting the finger did not end the stroke")
+	}
+	if firstRealMat != g.World.Dot[10][12] {
+		t.Errorf("tapped dot is %v, want %v",
+		         g.World.Dot[10][12], firstRealMat)
+	}
+	if n := countDots(&g.World, firstRealMat); n != 1 {
+		t.Errorf("tap painted %v dots, want 1", n)
+	}
+	if g.History.Pos != 1 {
+		t.Errorf("tap made %v history entries, want 1", g.History.Pos)
+	}
+}
+
+// The finger jumps further than a cell each tick,
+// yet the stroke is expected to leave no gaps.
+func TestTouchDrag(
+	t *testing.T,
+) {
+	var g = newTouchTestGame(t)
+
+	for x := 5; x <= 25; x += 5 {
+		g.HandleTouches([]touch{touchAt(0, x, 20)})
+	}
+	g.HandleTouches(nil)
+
+	for x := 5; x <= 25; x++ {
+		if firstRealMat != g.World.Dot[x][20] {
+			t.Errorf("drag left a gap at %v, 20", x)
+		}
+	}
+	if n := countDots(&g.World, firstRealMat); n != 21 {
+		t.Errorf("drag painted %v dots, want 21", n)
+	}
+	if g.History.Pos != 1 {
+		t.Errorf("drag made %v history entries, want 1", g.History.Pos)
+	}
+}
+
+// Each finger moves by a quarter step per tick,
+// so the distance between them changes by half a step.
+func pinchTouches(
+	i int,
+) []touch {
+	var d = i * touchPinchStep / (4 * testWorldScale)
+
+	return []touch{touchAt(0, 20 - d, 20), touchAt(1, 20 + d, 20)}
+}
+
+func TestTouchPinch(
+	t *testing.T,
+) {
+	var g = newTouchTestGame(t)
+
+	g.BrushRadius = 5
+
+	for i := 0; i <= 6; i++ {
+		g.HandleTouches(pinchTouches(i))
+	}
+	if g.BrushRadius != 8 {
+		t.Errorf("spreading made radius %v, want 8", g.BrushRadius)
+	}
+
+	for i := 6; i >= 0; i-- {
+		g.HandleTouches(pinchTouches(i))
+	}
+	if g.BrushRadius != 5 {
+		t.Errorf("pinching made radius %v, want 5", g.BrushRadius)
+	}
+
+	// lifting one finger after the other must not paint
+	g.HandleTouches([]touch{touchAt(1, 20, 20)})
+	g.HandleTouches(nil)
+
+	if n := countDots(&g.World, mat.None); n != testWorldW * testWorldH {
+		t.Errorf("pinch painted %v dots, want none",
+		         testWorldW * testWorldH - n)
+	}
+	if g.Stroke.Active || g.Touch.Gesture {
+		t.Error("lifting all fingers did not end the gesture")
+	}
+	if g.History.Pos != 0 {
+		t.Errorf("pinch made %v history entries, want 0", g.History.Pos)
+	}
+}
+
+// Two fingers dragging down over the Matbox scroll it back,
+// as turning the mouse wheel up does.
+func TestTouchPan(
+	t *testing.T,
+) {
+	var (
+		g = newTouchTestGame(t)
+		x = testMatboxX + testTile / 2
+	)
+
+	g.Matbox.Scroll = 3
+
+	for y := 0; y <= testTile * 2; y += testTile / 4 {
+		g.HandleTouches([]touch{
+			{ID: 0, X: x - 4, Y: 4 + y},
+			{ID: 1, X: x + 4, Y: 4 + y},
+		})
+	}
+	g.HandleTouches(nil)
+
+	if g.Matbox.Scroll != 1 {
+		t.Errorf("panning by 2 tiles made scroll %v, want 1",
+		         g.Matbox.Scroll)
+	}
+	if g.BrushRadius != 0 {
+		t.Errorf("panning changed the radius to %v", g.BrushRadius)
+	}
+	if n := countDots(&g.World, mat.None); n != testWorldW * testWorldH {
+		t.Errorf("panning painted %v dots, want none",
+		         testWorldW * testWorldH - n)
+	}
+}
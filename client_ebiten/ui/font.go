@@ -0,0 +1,531 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+// hawps UI elements
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"io/fs"
+	"path"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+)
+
+const (
+	FontFallback   = '?'
+	metricsPostfix = ".metrics"
+)
+
+// A Glyph is drawn at the pen position plus its offsets.
+// Advance is how far the pen moves after drawing the Glyph,
+// not counting any spacing or kerning.
+type Glyph struct {
+	Img     *ebiten.Image
+	Advance int
+	XOff    int
+	YOff    int
+}
+
+// Glyphs missing from a Font are drawn as the Fallback rune,
+// and if that is missing too, as an empty box.
+type Font struct {
+	Fallback   rune
+	Glyphs     map[rune]Glyph
+	Kerning    map[[2]rune]int
+	LineHeight int
+	missing    Glyph
+}
+
+// The font used by DrawText and friends.
+var font *Font
+
+func newFont(
+) *Font {
+	return &Font{
+		Fallback: FontFallback,
+		Glyphs:   make(map[rune]Glyph),
+		Kerning:  make(map[[2]rune]int),
+	}
+}
+
+// Loads a BDF font or a glyph sheet from fsys.
+// For a glyph sheet, the glyph positions are read from a metrics file,
+// which is the sheet path with its extension replaced by ".metrics".
+// Without a metrics file,
+// the sheet is read as the original 128 ASCII chars wide strip,
+// where the first row of pixels marks each chars width in white.
+// A metrics file next to a BDF font can add kerning and line height.
+func LoadFont(
+	fsys fs.FS,
+	fpath string,
+) (*Font, error) {
+	var (
+		err  error
+		ret  *Font
+	)
+
+	ret = newFont()
+
+	if strings.ToLower(path.Ext(fpath)) == ".bdf" {
+		err = ret.loadBDF(fsys, fpath)
+	} else {
+		err = ret.loadSheet(fsys, fpath)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ret.LineHeight <= 0 {
+		return nil, fmt.Errorf("%v: font has no line height", fpath)
+	}
+	ret.missing = newMissingGlyph(ret.LineHeight)
+
+	return ret, nil
+}
+
+// Returns the Glyph for r, or the fallback if r is not in the Font.
+func (f *Font) Glyph(
+	r rune,
+) Glyph {
+	if g, ok := f.Glyphs[r]; ok {
+		return g
+	}
+	if g, ok := f.Glyphs[f.Fallback]; ok {
+		return g
+	}
+	return f.missing
+}
+
+// Measures the widest line and the height of all lines.
+func (f *Font) TextSize(
+	text string,
+	spacing int,
+) (int, int) {
+	var (
+		h     int
+		lineW int
+		prev  rune = -1
+		w     int
+	)
+
+	h = f.LineHeight
+	for _, r := range text {
+		if '\n' == r {
+			lineW = 0
+			prev = -1
+			h += f.LineHeight
+			continue
+		}
+
+		if prev >= 0 {
+			lineW += spacing + f.Kerning[[2]rune{prev, r}]
+		}
+		lineW += f.Glyph(r).Advance
+		if lineW > w {
+			w = lineW
+		}
+		prev = r
+	}
+
+	return w, h
+}
+
+func (f *Font) DrawText(
+	target *ebiten.Image,
+	x, y int,
+	text string,
+	spacing int,
+) {
+	var (
+		g    Glyph
+		opt  ebiten.DrawImageOptions
+		penX int = x
+		prev rune = -1
+	)
+
+	for _, r := range text {
+		if '\n' == r {
+			penX = x
+			prev = -1
+			y += f.LineHeight
+			continue
+		}
+
+		if prev >= 0 {
+			penX += spacing + f.Kerning[[2]rune{prev, r}]
+		}
+
+		g = f.Glyph(r)
+		if g.Img != nil {
+			opt.GeoM.Reset()
+			opt.GeoM.Translate(float64(penX + g.XOff),
+			                   float64(y + g.YOff))
+			target.DrawImage(g.Img, &opt)
+		}
+		penX += g.Advance
+		prev = r
+	}
+}
+
+func (f *Font) loadBDF(
+	fsys  fs.FS,
+	fpath string,
+) error {
+	var (
+		ascent, descent int
+		bbxH, bbxW      int
+		bbxX, bbxY      int
+		bitmap          bool
+		bits            *image.Alpha
+		code            rune
+		dwidth          int
+		fontBBXH        int
+		fontBBXY        int
+		row             int
+	)
+
+	file, err := fsys.Open(fpath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s := bufio.NewScanner(file)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		fields := strings.Fields(s.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		bad := func() error {
+			return fmt.Errorf("%v:%v: malformed \"%v\"",
+			                  fpath, lineNum, fields[0])
+		}
+
+		if bitmap {
+			if "ENDCHAR" == fields[0] {
+				bitmap = false
+				if code < 0 {
+					continue
+				}
+				f.Glyphs[code] = Glyph{
+					Img:     ebiten.NewImageFromImage(bits),
+					Advance: dwidth,
+					XOff:    bbxX,
+					YOff:    ascent - bbxH - bbxY,
+				}
+				continue
+			}
+			if row >= bbxH {
+				return bad()
+			}
+			line, err := strconv.ParseUint(fields[0], 16, 64)
+			if err != nil {
+				return bad()
+			}
+			rowBits := len(fields[0]) * 4
+			for px := 0; px < bbxW && px < rowBits; px++ {
+				if line & (1 << (rowBits - 1 - px)) != 0 {
+					bits.SetAlpha(px, row, color.Alpha{255})
+				}
+			}
+			row++
+			continue
+		}
+
+		switch fields[0] {
+		case "FONTBOUNDINGBOX":
+			if len(fields) < 5 {
+				return bad()
+			}
+			fontBBXH, _ = strconv.Atoi(fields[2])
+			fontBBXY, _ = strconv.Atoi(fields[4])
+
+		case "FONT_ASCENT":
+			if len(fields) < 2 {
+				return bad()
+			}
+			ascent, _ = strconv.Atoi(fields[1])
+
+		case "FONT_DESCENT":
+			if len(fields) < 2 {
+				return bad()
+			}
+			descent, _ = strconv.Atoi(fields[1])
+
+		case "STARTCHAR":
+			code = -1
+			dwidth = 0
+			bbxW, bbxH, bbxX, bbxY = 0, 0, 0, 0
+
+		case "ENCODING":
+			if len(fields) < 2 {
+				return bad()
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return bad()
+			}
+			code = rune(n)
+
+		case "DWIDTH":
+			if len(fields) < 2 {
+				return bad()
+			}
+			dwidth, _ = strconv.Atoi(fields[1])
+
+		case "BBX":
+			if len(fields) < 5 {
+				return bad()
+			}
+			bbxW, _ = strconv.Atoi(fields[1])
+			bbxH, _ = strconv.Atoi(fields[2])
+			bbxX, _ = strconv.Atoi(fields[3])
+			bbxY, _ = strconv.Atoi(fields[4])
+
+		case "BITMAP":
+			if 0 == ascent && 0 == descent {
+				ascent = fontBBXH + fontBBXY
+				descent = -fontBBXY
+			}
+			bitmap = true
+			row = 0
+			bits = image.NewAlpha(image.Rect(0, 0,
+			                                 max(bbxW, 1),
+			                                 max(bbxH, 1)))
+		}
+	}
+	if err = s.Err(); err != nil {
+		return err
+	}
+
+	f.LineHeight = ascent + descent
+
+	return f.loadMetrics(fsys, fpath, nil)
+}
+
+// Metrics files are line based, with "#" starting a comment.
+// Runes are written as U+XXXX or as the char itself.
+//
+//	lineheight PIXELS
+//	fallback RUNE
+//	glyph RUNE X Y W H [ADVANCE [XOFF YOFF]]
+//	kern RUNE RUNE PIXELS
+//
+// Glyph lines cut the glyph out of sheet, and are an error without one.
+func (f *Font) loadMetrics(
+	fsys  fs.FS,
+	fpath string,
+	sheet *ebiten.Image,
+) error {
+	var (
+		mpath string
+		n     [7]int
+	)
+
+	mpath = strings.TrimSuffix(fpath, path.Ext(fpath)) + metricsPostfix
+
+	file, err := fsys.Open(mpath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	s := bufio.NewScanner(file)
+	for lineNum := 1; s.Scan(); lineNum++ {
+		line, _, _ := strings.Cut(s.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		bad := func() error {
+			return fmt.Errorf("%v:%v: malformed \"%v\"",
+			                  mpath, lineNum, fields[0])
+		}
+
+		switch fields[0] {
+		case "lineheight":
+			if len(fields) != 2 {
+				return bad()
+			}
+			f.LineHeight, err = strconv.Atoi(fields[1])
+			if err != nil {
+				return bad()
+			}
+
+		case "fallback":
+			if len(fields) != 2 {
+				return bad()
+			}
+			f.Fallback, err = parseRune(fields[1])
+			if err != nil {
+				return bad()
+			}
+
+		case "glyph":
+			if sheet == nil || len(fields) < 6 || len(fields) > 9 ||
+			   len(fields) == 8 {
+				return bad()
+			}
+			r, err := parseRune(fields[1])
+			if err != nil {
+				return bad()
+			}
+			for i := 2; i < len(fields); i++ {
+				n[i - 2], err = strconv.Atoi(fields[i])
+				if err != nil {
+					return bad()
+				}
+			}
+			if len(fields) < 7 {
+				n[4] = n[2]
+			}
+			if len(fields) < 9 {
+				n[5], n[6] = 0, 0
+			}
+			rect := image.Rect(n[0], n[1], n[0] + n[2], n[1] + n[3])
+			if !rect.In(sheet.Bounds()) {
+				return fmt.Errorf("%v:%v: glyph outside of sheet",
+				                  mpath, lineNum)
+			}
+			f.Glyphs[r] = Glyph{
+				Img:     sheet.SubImage(rect).(*ebiten.Image),
+				Advance: n[4],
+				XOff:    n[5],
+				YOff:    n[6],
+			}
+
+		case "kern":
+			if len(fields) != 4 {
+				return bad()
+			}
+			a, err := parseRune(fields[1])
+			if err != nil {
+				return bad()
+			}
+			b, err := parseRune(fields[2])
+			if err != nil {
+				return bad()
+			}
+			f.Kerning[[2]rune{a, b}], err = strconv.Atoi(fields[3])
+			if err != nil {
+				return bad()
+			}
+
+		default:
+			return bad()
+		}
+	}
+
+	return s.Err()
+}
+
+func (f *Font) loadSheet(
+	fsys  fs.FS,
+	fpath string,
+) error {
+	var (
+		charW int
+		rect  image.Rectangle
+	)
+
+	sheet, sheetraw, err := ebitenutil.NewImageFromFileSystem(fsys, fpath)
+	if err != nil {
+		return err
+	}
+
+	err = f.loadMetrics(fsys, fpath, sheet)
+	if err != nil {
+		return err
+	}
+	if len(f.Glyphs) > 0 {
+		return nil
+	}
+
+	if sheet.Bounds().Dx() < FontMaxChars * FontCharMaxW ||
+	   sheet.Bounds().Dy() < FontCharY + FontCharMaxH {
+		return fmt.Errorf("%v: glyph sheet without metrics must be "+
+		                  "at least %vx%v", fpath,
+		                  FontMaxChars * FontCharMaxW,
+		                  FontCharY + FontCharMaxH)
+	}
+
+	if 0 == f.LineHeight {
+		f.LineHeight = FontCharMaxH
+	}
+
+	rect.Min.Y = FontCharY
+	rect.Max.Y = rect.Min.Y + FontCharMaxH
+
+	for i := 0; i < FontMaxChars; i++ {
+		for charW = 0; charW < FontCharMaxW; charW++ {
+			if sheetraw.At(i * FontCharMaxW + charW, 0) !=
+			   sheetraw.ColorModel().Convert(color.White) {
+				break
+			}
+		}
+		rect.Min.X = i * FontCharMaxW
+		rect.Max.X = rect.Min.X + charW
+		f.Glyphs[rune(i)] = Glyph{
+			Img:     ebiten.NewImageFromImage(sheet.SubImage(rect)),
+			Advance: charW,
+		}
+	}
+
+	return nil
+}
+
+// An outlined box, so that missing glyphs are still visible.
+func newMissingGlyph(
+	lineHeight int,
+) Glyph {
+	var (
+		h   = max(lineHeight - 2, 2)
+		img *image.Alpha
+		w   = max(h / 2, 2)
+	)
+
+	img = image.NewAlpha(image.Rect(0, 0, w, h))
+	for x := 0; x < w; x++ {
+		img.SetAlpha(x, 0, color.Alpha{255})
+		img.SetAlpha(x, h - 1, color.Alpha{255})
+	}
+	for y := 0; y < h; y++ {
+		img.SetAlpha(0, y, color.Alpha{255})
+		img.SetAlpha(w - 1, y, color.Alpha{255})
+	}
+
+	return Glyph{
+		Img:     ebiten.NewImageFromImage(img),
+		Advance: w,
+		YOff:    1,
+	}
+}
+
+func parseRune(
+	s string,
+) (rune, error) {
+	if strings.HasPrefix(s, "U+") {
+		n, err := strconv.ParseUint(s[2:], 16, 32)
+		return rune(n), err
+	}
+
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError || size != len(s) {
+		return 0, fmt.Errorf("\"%v\" is not a single rune", s)
+	}
+
+	return r, nil
+}
@@ -196,6 +196,11 @@ func (t *TileSet) HandleWheel(
 	return true
 }
 
+func (t TileSet) Horizontal(
+) bool {
+	return t.horizontal
+}
+
 func (t TileSet) Size() image.Point {
 	return t.Img.Bounds().Size()
 }
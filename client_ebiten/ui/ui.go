@@ -5,12 +5,9 @@
 package ui
 
 import (
-	"embed"
-	"image"
-	"image/color"
+	"io/fs"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 )
 
 const (
@@ -20,25 +17,19 @@ const (
 	FontMaxChars = 128
 )
 
-var fontChars [FontMaxChars]*ebiten.Image
-
 func DrawnTextLen(
 	text string,
 	spacing int,
 ) int {
-	var (
-		i int
-		w int
-		ret int
-	)
-
-	for i = 0; i < len(text); i++ {
-		w, _ = fontChars[text[i]].Size()
-		ret += w + spacing
-	}
-	ret -= spacing
+	w, _ := font.TextSize(text, spacing)
+	return w
+}
 
-	return ret
+func DrawnTextH(
+	text string,
+) int {
+	_, h := font.TextSize(text, 0)
+	return h
 }
 
 func DrawText(
@@ -47,52 +38,32 @@ func DrawText(
 	text string,
 	spacing int,
 ) {
-	var (
-		char *ebiten.Image
-		i int
-		opt ebiten.DrawImageOptions
-		w int
-	)
+	font.DrawText(target, x, y, text, spacing)
+}
 
-	for i = 0; i < len(text); i++ {
-		char = fontChars[text[i]]
-		opt.GeoM.Reset()
-		opt.GeoM.Translate(float64(x), float64(y))
-		target.DrawImage(char, &opt)
-		w, _ = char.Size()
-		x += w + spacing
-	}
+// Returns the font used by DrawText and friends.
+func GetFont(
+) *Font {
+	return font
 }
 
+// Loads the default font, which SetFont can replace later.
 func Init(
-	fs embed.FS,
+	fsys fs.FS,
 	path string,
 ) {
-	var (
-		charW int
-		i int
-		rect  image.Rectangle
-	)
+	var err error
 
-	fontimg, fontimgraw, err := ebitenutil.NewImageFromFileSystem(fs, path)
+	font, err = LoadFont(fsys, path)
 	if err != nil {
 		panic(err)
 	}
+}
 
-	rect.Min.Y = FontCharY
-	rect.Max.Y = rect.Min.Y + FontCharMaxH
-
-	for i = 0; i < len(fontChars); i++ {
-		for charW = 0; charW < FontCharMaxW; charW++ {
-			if fontimgraw.At(i * FontCharMaxW + charW, 0) !=
-			   fontimgraw.ColorModel().Convert(color.White) {
-				break
-			}
-		}
-		rect.Min.X = i * FontCharMaxW
-		rect.Max.X = rect.Min.X + charW
-		fontChars[i] = ebiten.NewImageFromImage(fontimg.SubImage(rect))
-	}
+func SetFont(
+	f *Font,
+) {
+	font = f
 }
 
 func pointInRect(px, py, x, y, w, h int) bool {
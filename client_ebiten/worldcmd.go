@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: MPL-2.0
+// Copyright (C) 2024 - 2026  Andy Frank Schoknecht
+
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+
+	"github.com/SchokiCoder/hawps/core/mat"
+	"github.com/SchokiCoder/hawps/client_ebiten/ui"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	confirmBgR = 20
+	confirmBgG = 20
+	confirmBgB = 30
+	confirmBgA = 230
+	confirmPad = 6
+
+	confirmHint = "Enter confirms, Escape cancels"
+)
+
+// A command that changes the whole world at once.
+// Since it can't be taken back by just releasing the mouse button,
+// it is only run once confirmed.
+type worldCmd struct {
+	Question string
+	Run      func()
+}
+
+// Opens the confirmation of a world command, for a just pressed key.
+// F1 clears everything, F2 removes all dots of the mat under the cursor,
+// F3 removes all spawners, F4 asks for a temperature to set every dot to
+// and F5 resets the oxidation and dissolution of every dot.
+func (g *physGame) HandleWorldCmdKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyF1:
+		g.Confirm = &worldCmd{
+			Question: "Clear the whole world?",
+			Run:      g.World.Clear,
+		}
+
+	case ebiten.KeyF2:
+		x, y := g.HoverPos()
+		if !g.InWorldBounds(x, y) || mat.None == g.World.Dot[x][y] {
+			return
+		}
+		m := g.World.Dot[x][y]
+		g.Confirm = &worldCmd{
+			Question: "Remove all " + mat.Name(m) + "?",
+			Run:      func() {g.World.ClearMat(m)},
+		}
+
+	case ebiten.KeyF3:
+		g.Confirm = &worldCmd{
+			Question: "Remove all spawners?",
+			Run:      g.World.ClearSpawners,
+		}
+
+	case ebiten.KeyF4:
+		g.StartTextInput("temperature of every dot", g.ConfirmSetThermo)
+
+	case ebiten.KeyF5:
+		g.Confirm = &worldCmd{
+			Question: "Reset oxidation and dissolution?",
+			Run:      g.World.ResetProgress,
+		}
+
+	default:
+		return
+	}
+
+	g.CancelStroke()
+}
+
+// Asks to confirm setting every dot to the typed temperature,
+// in Kelvin, or in Celsius when followed by a C.
+func (g *physGame) ConfirmSetThermo(
+	text string,
+) {
+	t, err := parseTemperature(text)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not set temperature: %v\n", err)
+		return
+	}
+
+	g.Confirm = &worldCmd{
+		Question: "Set every dot to " + g.FormatT(t) + "?",
+		Run:      func() {g.World.SetThermo(t)},
+	}
+}
+
+// Handles a just pressed key, while a world command waits for confirmation.
+func (g *physGame) HandleConfirmKey(
+	key ebiten.Key,
+) {
+	switch key {
+	case ebiten.KeyEnter: fallthrough
+	case ebiten.KeyNumpadEnter:
+		g.RunWorldCmd(g.Confirm.Run)
+		g.Confirm = nil
+
+	case ebiten.KeyEscape:
+		g.Confirm = nil
+	}
+}
+
+// Runs a world command as one undoable stroke.
+func (g *physGame) RunWorldCmd(
+	run func(),
+) {
+	g.History.Begin(&g.World)
+	for x := 0; x < g.World.W; x++ {
+		for y := 0; y < g.World.H; y++ {
+			g.History.Touch(&g.World, x, y)
+		}
+	}
+
+	run()
+
+	g.History.End(&g.World)
+}
+
+// Shows the question of the world command in the middle of the world.
+func (g physGame) drawConfirm(
+	screen *ebiten.Image,
+) {
+	g.drawDialog(screen, g.Confirm.Question, confirmHint)
+}
+
+// Shows a box with two lines of text in the middle of the world.
+func (g physGame) drawDialog(
+	screen *ebiten.Image,
+	text   string,
+	hint   string,
+) {
+	var (
+		lineH = ui.DrawnTextH(hint)
+		textW = max(ui.DrawnTextLen(text, uiFontSpacing),
+		            ui.DrawnTextLen(hint, uiFontSpacing))
+		w     = textW + confirmPad * 2
+		h     = lineH * 2 + confirmPad * 3
+		x     = g.WorldX + (g.World.W * g.WorldScale - w) / 2
+		y     = g.WorldY + (g.World.H * g.WorldScale - h) / 2
+	)
+
+	vector.DrawFilledRect(screen,
+	                      float32(x),
+	                      float32(y),
+	                      float32(w),
+	                      float32(h),
+	                      color.RGBA{confirmBgR,
+	                                 confirmBgG,
+	                                 confirmBgB,
+	                                 confirmBgA},
+	                      false)
+
+	ui.DrawText(screen,
+	            x + confirmPad,
+	            y + confirmPad,
+	            text,
+	            uiFontSpacing)
+	ui.DrawText(screen,
+	            x + confirmPad,
+	            y + confirmPad * 2 + lineH,
+	            hint,
+	            uiFontSpacing)
+}
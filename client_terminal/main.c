@@ -957,6 +957,21 @@ handle_advanced_command(const char          *cmd,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			set_feedback(feedback, feedback_expiration, now,
 			             "Unsupported tool selected.");
@@ -1596,6 +1611,21 @@ handle_mouse_input(const char         *in,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1755,6 +1785,21 @@ handle_normal_input(const char         *in,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1773,6 +1818,21 @@ handle_normal_input(const char         *in,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1795,6 +1855,21 @@ handle_normal_input(const char         *in,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1813,6 +1888,21 @@ handle_normal_input(const char         *in,
 		case TOOL_ERASER:
 		case TOOL_HEATER:
 		case TOOL_COOLER:
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1916,6 +2006,21 @@ handle_normal_input(const char         *in,
 			tool_opts->thermo_radius = 0;
 			break;
 
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -1943,6 +2048,21 @@ handle_normal_input(const char         *in,
 			tool_opts->thermo_radius = MAX_RADIUS;
 			break;
 
+		case TOOL_LINE:
+		case TOOL_RECTANGLE:
+		case TOOL_CIRCLE:
+		case TOOL_FILL:
+		case TOOL_SELECT:
+		case TOOL_PREFAB:
+		case TOOL_DRAIN:
+		case TOOL_HEAT_SOURCE:
+		case TOOL_HEAT_SINK:
+		case TOOL_HAMMER:
+		case TOOL_IGNITER:
+		case TOOL_EYEDROPPER:
+		case TOOL_MIXTURE:
+		case TOOL_LOCK:
+		case TOOL_TEXT:
 		case TOOL_COUNT:
 			break;
 		}
@@ -2117,8 +2237,6 @@ handle_simple_command(const char          *cmdline,
                       struct ToolOptions  *tool_opts,
                       struct World        *world)
 {
-	int x, y;
-
 	*feedback = NULL;
 
 	if (strcmp(cmdline, CMD_BRUSH) == 0 ||
@@ -2126,19 +2244,11 @@ handle_simple_command(const char          *cmdline,
 		tool_opts->sel_tool = TOOL_BRUSH;
 	} else if (strcmp(cmdline, CMD_CLEAR) == 0 ||
 	           strcmp(cmdline, CMD_CLEAR_SHORT) == 0) {
-		for (x = 0; x < world->w; x++) {
-			for (y = 0; y < world->h; y++) {
-				world_clear_dot(world, x, y);
-			}
-		}
+		world_clear_dots(world);
 	} else if (strcmp(cmdline, CMD_CLEARALL) == 0 ||
 	           strcmp(cmdline, CMD_CLEARALL_SHORT) == 0) {
-		for (x = 0; x < world->w; x++) {
-			for (y = 0; y < world->h; y++) {
-				world_clear_dot(world, x, y);
-				world->spawner[x][y] = false;
-			}
-		}
+		world_clear_dots(world);
+		world_clear_spawners(world);
 	} else if (strcmp(cmdline, CMD_COOLER) == 0 ||
 	           strcmp(cmdline, CMD_COOLER_SHORT) == 0) {
 		tool_opts->sel_tool = TOOL_COOLER;
@@ -2221,7 +2331,6 @@ tool_radius_add(const int           radius_change,
 
 	case TOOL_SPAWNER:
 		return;
-		break;
 
 	case TOOL_ERASER:
 		target = &tool_opts->eraser_radius;
@@ -2232,8 +2341,23 @@ tool_radius_add(const int           radius_change,
 		target = &tool_opts->thermo_radius;
 		break;
 
+	case TOOL_LINE:
+	case TOOL_RECTANGLE:
+	case TOOL_CIRCLE:
+	case TOOL_FILL:
+	case TOOL_SELECT:
+	case TOOL_PREFAB:
+	case TOOL_DRAIN:
+	case TOOL_HEAT_SOURCE:
+	case TOOL_HEAT_SINK:
+	case TOOL_HAMMER:
+	case TOOL_IGNITER:
+	case TOOL_EYEDROPPER:
+	case TOOL_MIXTURE:
+	case TOOL_LOCK:
+	case TOOL_TEXT:
 	case TOOL_COUNT:
-		break;
+		return;
 	}
 
 	*target += radius_change;
@@ -2480,6 +2604,21 @@ render_world(char               *out,
 	case TOOL_COOLER:
 		tool_radius = tool_opts.thermo_radius;
 		break;
+	case TOOL_LINE:
+	case TOOL_RECTANGLE:
+	case TOOL_CIRCLE:
+	case TOOL_FILL:
+	case TOOL_SELECT:
+	case TOOL_PREFAB:
+	case TOOL_DRAIN:
+	case TOOL_HEAT_SOURCE:
+	case TOOL_HEAT_SINK:
+	case TOOL_HAMMER:
+	case TOOL_IGNITER:
+	case TOOL_EYEDROPPER:
+	case TOOL_MIXTURE:
+	case TOOL_LOCK:
+	case TOOL_TEXT:
 	case TOOL_COUNT:
 		break;
 	}
@@ -2537,8 +2676,14 @@ use_tool(const float         delta,
 		break;
 
 	case TOOL_SPAWNER:
-		world->spawner[tool_opts.x][tool_opts.y] = true;
-		world->spawner_mat[tool_opts.x][tool_opts.y] = tool_opts.spawner_mat;
+		world_set_spawner(world,
+		                  tool_opts.spawner_mat,
+		                  tool_opts.spawn_temperature,
+		                  tool_opts.x,
+		                  tool_opts.y,
+		                  1,
+		                  100,
+		                  0);
 		break;
 
 	case TOOL_ERASER:
@@ -2564,6 +2709,21 @@ use_tool(const float         delta,
 		                 tool_opts.thermo_radius);
 		break;
 
+	case TOOL_LINE:
+	case TOOL_RECTANGLE:
+	case TOOL_CIRCLE:
+	case TOOL_FILL:
+	case TOOL_SELECT:
+	case TOOL_PREFAB:
+	case TOOL_DRAIN:
+	case TOOL_HEAT_SOURCE:
+	case TOOL_HEAT_SINK:
+	case TOOL_HAMMER:
+	case TOOL_IGNITER:
+	case TOOL_EYEDROPPER:
+	case TOOL_MIXTURE:
+	case TOOL_LOCK:
+	case TOOL_TEXT:
 	case TOOL_COUNT:
 		break;
 	}
@@ -2696,7 +2856,7 @@ main(int    argc,
 		if (now - last_tick >= (long) (CLOCKS_PER_SEC / tickrate)) {
 			last_tick = now;
 
-			world_update(&world, tool_opts.spawn_temperature);
+			world_update(&world);
 
 			if (!paused) {
 				world_sim(&world);
@@ -238,7 +238,7 @@ tick(ClientData data)
 {
 	Tcl_Interp *interp = data;
 
-	world_update(&world, STD_TEMPERATURE);
+	world_update(&world);
 	world_sim(&world);
 	world_draw(interp);
 }
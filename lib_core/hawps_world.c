@@ -10,6 +10,7 @@
 #define WEIGHT_FACTOR_LIQUID 0.95
 #define WEIGHT_FACTOR_GAS    0.90
 #define WEIGHTLOSS_LIMIT_GAS 5000.0
+#define IGNITER_EXCESS       10.0
 
 static bool
 world_collapse_gas_stack(struct World *w,
@@ -40,6 +41,24 @@ world_drop_liquid(struct World *w,
                   const int     x,
                   const int     y);
 
+/* Places a new dot, as every drawing tool does.
+ */
+static void
+world_set_dot(struct World   *w,
+              const enum Mat  m,
+              const float     t,
+              const int       x,
+              const int       y);
+
+static void
+world_sim_boundaries(struct World *w);
+
+static void
+world_sim_th_fixed(struct World *w,
+                   const int     x,
+                   const int     y,
+                   const float   t);
+
 static void
 world_sim_to_right(struct World *w,
                    int          *x,
@@ -83,6 +102,11 @@ world_update_dot_from_thermo(struct World *w,
                              const int     x,
                              const int     y);
 
+static void
+world_update_emitter(struct World *w,
+                     const int     x,
+                     const int     y);
+
 struct World
 world_new(const int   w,
           const int   h,
@@ -92,32 +116,59 @@ world_new(const int   w,
 	int y;
 
 	struct World ret = {
-		.w =            w,
-		.h =            h,
-		.dissol =       calloc(w, sizeof(float*)),
-		._dissol =      calloc(w * h, sizeof(float)),
-		.dot =          calloc(w, sizeof(enum Mat*)),
-		._dot =         calloc(w * h, sizeof(enum Mat)),
-		.oxid =         calloc(w, sizeof(float*)),
-		._oxid =        calloc(w * h, sizeof(float)),
-		.spawner =      calloc(w, sizeof(int*)),
-		._spawner =     calloc(w * h, sizeof(int)),
-		.spawner_mat =  calloc(w, sizeof(enum Mat*)),
-		._spawner_mat = calloc(w * h, sizeof(enum Mat)),
-		.state =        calloc(w, sizeof(enum MatState*)),
-		._state =       calloc(w * h, sizeof(enum MatState)),
-		.thermo =       calloc(w, sizeof(float*)),
-		._thermo =      calloc(w * h, sizeof(float)),
-		.weight =       calloc(w, sizeof(float*)),
-		._weight =      calloc(w * h, sizeof(float))
+		.w =                 w,
+		.h =                 h,
+		.dissol =            calloc(w, sizeof(float*)),
+		._dissol =           calloc(w * h, sizeof(float)),
+		.dot =               calloc(w, sizeof(enum Mat*)),
+		._dot =              calloc(w * h, sizeof(enum Mat)),
+		.emitter =           calloc(w, sizeof(enum Emitter*)),
+		._emitter =          calloc(w * h, sizeof(enum Emitter)),
+		.emitter_fixed =     calloc(w, sizeof(bool*)),
+		._emitter_fixed =    calloc(w * h, sizeof(bool)),
+		.emitter_mat =       calloc(w, sizeof(enum Mat*)),
+		._emitter_mat =      calloc(w * h, sizeof(enum Mat)),
+		.emitter_thermo =    calloc(w, sizeof(float*)),
+		._emitter_thermo =   calloc(w * h, sizeof(float)),
+		.lock =              calloc(w, sizeof(enum Lock*)),
+		._lock =             calloc(w * h, sizeof(enum Lock)),
+		.oxid =              calloc(w, sizeof(float*)),
+		._oxid =             calloc(w * h, sizeof(float)),
+		.spawner =           calloc(w, sizeof(int*)),
+		._spawner =          calloc(w * h, sizeof(int)),
+		.spawner_burst =     calloc(w, sizeof(int*)),
+		._spawner_burst =    calloc(w * h, sizeof(int)),
+		.spawner_chance =    calloc(w, sizeof(int*)),
+		._spawner_chance =   calloc(w * h, sizeof(int)),
+		.spawner_interval =  calloc(w, sizeof(int*)),
+		._spawner_interval = calloc(w * h, sizeof(int)),
+		.spawner_mat =       calloc(w, sizeof(enum Mat*)),
+		._spawner_mat =      calloc(w * h, sizeof(enum Mat)),
+		.spawner_thermo =    calloc(w, sizeof(float*)),
+		._spawner_thermo =   calloc(w * h, sizeof(float)),
+		.state =             calloc(w, sizeof(enum MatState*)),
+		._state =            calloc(w * h, sizeof(enum MatState)),
+		.thermo =            calloc(w, sizeof(float*)),
+		._thermo =           calloc(w * h, sizeof(float)),
+		.weight =            calloc(w, sizeof(float*)),
+		._weight =           calloc(w * h, sizeof(float))
 	};
 
 	for (x = 0; x < w; x++) {
 		ret.dissol[x] = &ret._dissol[x * h];
 		ret.dot[x] = &ret._dot[x * h];
+		ret.emitter[x] = &ret._emitter[x * h];
+		ret.emitter_fixed[x] = &ret._emitter_fixed[x * h];
+		ret.emitter_mat[x] = &ret._emitter_mat[x * h];
+		ret.emitter_thermo[x] = &ret._emitter_thermo[x * h];
+		ret.lock[x] = &ret._lock[x * h];
 		ret.oxid[x] = &ret._oxid[x * h];
 		ret.spawner[x] = &ret._spawner[x * h];
+		ret.spawner_burst[x] = &ret._spawner_burst[x * h];
+		ret.spawner_chance[x] = &ret._spawner_chance[x * h];
+		ret.spawner_interval[x] = &ret._spawner_interval[x * h];
 		ret.spawner_mat[x] = &ret._spawner_mat[x * h];
+		ret.spawner_thermo[x] = &ret._spawner_thermo[x * h];
 		ret.state[x] = &ret._state[x * h];
 		ret.thermo[x] = &ret._thermo[x * h];
 		ret.weight[x] = &ret._weight[x * h];
@@ -137,6 +188,10 @@ world_can_displace(struct World *w,
                    const int     dx,
                    const int     dy)
 {
+	if (LOCK_FROZEN == w->lock[dx][dy]) {
+		return false;
+	}
+
 	if (MAT_NONE == w->dot[dx][dy]) {
 		return true;
 	}
@@ -166,6 +221,24 @@ world_can_displace(struct World *w,
 	return false;
 }
 
+void
+world_clear(struct World *w)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
+			world_clear_dot(w, x, y);
+			w->emitter[x][y] = EMITTER_NONE;
+			w->spawner[x][y] = false;
+		}
+	}
+}
+
 void
 world_clear_dot(struct World *w,
                 const int     x,
@@ -176,6 +249,55 @@ world_clear_dot(struct World *w,
 	w->thermo[x][y] = 0;
 }
 
+void
+world_clear_dots(struct World *w)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
+			world_clear_dot(w, x, y);
+		}
+	}
+}
+
+void
+world_clear_mat(struct World   *w,
+                const enum Mat  m)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y] || m != w->dot[x][y]) {
+				continue;
+			}
+
+			world_clear_dot(w, x, y);
+		}
+	}
+}
+
+void
+world_clear_spawners(struct World *w)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
+			w->spawner[x][y] = false;
+		}
+	}
+}
+
 static bool
 world_collapse_gas_stack(struct World *w,
                          const int     x,
@@ -189,7 +311,8 @@ world_collapse_gas_stack(struct World *w,
 	}
 
 	if (MS_STATIC == w->state[dx][dy] ||
-	    MS_GRAIN == w->state[dx][dy]) {
+	    MS_GRAIN == w->state[dx][dy] ||
+	    LOCK_FROZEN == w->lock[dx][dy]) {
 		return true;
 	}
 
@@ -209,7 +332,8 @@ world_collapse_liquid_stack(struct World *w,
 	}
 
 	if (MS_STATIC == w->state[dx][dy] ||
-	    MS_GRAIN == w->state[dx][dy]) {
+	    MS_GRAIN == w->state[dx][dy] ||
+	    LOCK_FROZEN == w->lock[dx][dy]) {
 		return true;
 	}
 
@@ -304,6 +428,115 @@ world_drop_liquid(struct World *w,
 	}
 }
 
+static void
+world_set_dot(struct World   *w,
+              const enum Mat  m,
+              const float     t,
+              const int       x,
+              const int       y)
+{
+	if (LOCK_NONE != w->lock[x][y]) {
+		return;
+	}
+
+	w->dissol[x][y] = 0.0;
+	w->dot[x][y] = m;
+	w->oxid[x][y] = 0.0;
+	w->thermo[x][y] = t;
+
+	if (w->thermo[x][y] >= MAT_BOIL_P[w->dot[x][y]]) {
+		if (MAT_MELT_DECOMP[w->dot[x][y]]) {
+			w->dot[x][y] = mat_melt_prdct(w->dot[x][y]);
+		}
+	}
+}
+
+void
+world_reset_progress(struct World *w)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
+			w->dissol[x][y] = 0;
+			w->oxid[x][y] = 0;
+		}
+	}
+}
+
+void
+world_set_boundary(struct World        *w,
+                   const enum Edge      e,
+                   const enum Boundary  b,
+                   const float          t)
+{
+	w->boundary[e] = b;
+	w->boundary_thermo[e] = t;
+}
+
+void
+world_set_emitter(struct World       *w,
+                  const enum Emitter  e,
+                  const enum Mat      m,
+                  const float         t,
+                  const bool          fixed,
+                  const int           x,
+                  const int           y)
+{
+	if (LOCK_NONE != w->lock[x][y]) {
+		return;
+	}
+
+	w->emitter[x][y] = e;
+	w->emitter_fixed[x][y] = fixed;
+	w->emitter_mat[x][y] = m;
+	w->emitter_thermo[x][y] = t;
+}
+
+void
+world_set_spawner(struct World   *w,
+                  const enum Mat  m,
+                  const float     t,
+                  const int       x,
+                  const int       y,
+                  const int       interval,
+                  const int       chance,
+                  const int       burst)
+{
+	if (LOCK_NONE != w->lock[x][y]) {
+		return;
+	}
+
+	w->spawner[x][y] = true;
+	w->spawner_burst[x][y] = burst;
+	w->spawner_chance[x][y] = chance;
+	w->spawner_interval[x][y] = interval < 1 ? 1 : interval;
+	w->spawner_mat[x][y] = m;
+	w->spawner_thermo[x][y] = t;
+}
+
+void
+world_set_thermo(struct World *w,
+                 const float   t)
+{
+	int x, y;
+
+	for (x = 0; x < w->w; x++) {
+		for (y = 0; y < w->h; y++) {
+			if (LOCK_NONE != w->lock[x][y] ||
+			    MAT_NONE == w->dot[x][y]) {
+				continue;
+			}
+
+			w->thermo[x][y] = t;
+		}
+	}
+}
+
 void
 world_sim(struct World *w)
 {
@@ -375,6 +608,122 @@ world_sim(struct World *w)
 		world_sim_chemical_reaction(w, x, y, x - 1, y);
 		world_sim_gravity(w, x, y);
 	}
+
+	world_sim_boundaries(w);
+}
+
+/* The loops of world_sim treat every edge as a wall,
+ * so this only needs to add what the other boundaries do on top of that.
+ */
+static void
+world_sim_boundaries(struct World *w)
+{
+	int  x, y;
+	int  b = w->h - 1;
+	int  r = w->w - 1;
+	bool wrap_h = BOUNDARY_WRAP == w->boundary[EDGE_LEFT] ||
+	              BOUNDARY_WRAP == w->boundary[EDGE_RIGHT];
+	bool wrap_v = BOUNDARY_WRAP == w->boundary[EDGE_TOP] ||
+	              BOUNDARY_WRAP == w->boundary[EDGE_BOTTOM];
+
+	for (x = 0; x < w->w; x++) {
+		if (BOUNDARY_VOID == w->boundary[EDGE_TOP] &&
+		    LOCK_FROZEN != w->lock[x][0] &&
+		    MAT_NONE != w->dot[x][0] &&
+		    MS_GAS == w->state[x][0]) {
+			world_clear_dot(w, x, 0);
+		} else if (BOUNDARY_FIXED_T == w->boundary[EDGE_TOP]) {
+			world_sim_th_fixed(w, x, 0, w->boundary_thermo[EDGE_TOP]);
+		}
+
+		if (BOUNDARY_VOID == w->boundary[EDGE_BOTTOM] &&
+		    LOCK_FROZEN != w->lock[x][b] &&
+		    MAT_NONE != w->dot[x][b] &&
+		    MS_STATIC != w->state[x][b]) {
+			world_clear_dot(w, x, b);
+		} else if (BOUNDARY_FIXED_T == w->boundary[EDGE_BOTTOM]) {
+			world_sim_th_fixed(w, x, b, w->boundary_thermo[EDGE_BOTTOM]);
+		}
+
+		if (!wrap_v) {
+			continue;
+		}
+
+		if (MAT_NONE != w->dot[x][b]) {
+			world_sim_th_conduction(w, x, b, x, 0);
+		} else if (MAT_NONE != w->dot[x][0]) {
+			world_sim_th_conduction(w, x, 0, x, b);
+		}
+
+		/* gases rise out of the top, everything else falls out of the
+		 * bottom, so no dot moves back and forth between the two
+		 */
+		if (MAT_NONE != w->dot[x][b] &&
+		    MS_STATIC != w->state[x][b] &&
+		    MS_GAS != w->state[x][b] &&
+		    LOCK_FROZEN != w->lock[x][b] &&
+		    world_can_displace(w, x, b, x, 0)) {
+			world_swap_dots(w, x, b, x, 0);
+		} else if (MAT_NONE != w->dot[x][0] &&
+		           MS_GAS == w->state[x][0] &&
+		           LOCK_FROZEN != w->lock[x][0] &&
+		           world_can_displace(w, x, 0, x, b)) {
+			world_swap_dots(w, x, 0, x, b);
+		}
+	}
+
+	for (y = 0; y < w->h; y++) {
+		if (BOUNDARY_VOID == w->boundary[EDGE_LEFT] &&
+		    y < b &&
+		    LOCK_FROZEN != w->lock[0][y] &&
+		    MAT_NONE != w->dot[0][y] &&
+		    MS_STATIC != w->state[0][y]) {
+			world_clear_dot(w, 0, y);
+		} else if (BOUNDARY_FIXED_T == w->boundary[EDGE_LEFT]) {
+			world_sim_th_fixed(w, 0, y, w->boundary_thermo[EDGE_LEFT]);
+		}
+
+		if (BOUNDARY_VOID == w->boundary[EDGE_RIGHT] &&
+		    y < b &&
+		    LOCK_FROZEN != w->lock[r][y] &&
+		    MAT_NONE != w->dot[r][y] &&
+		    MS_STATIC != w->state[r][y]) {
+			world_clear_dot(w, r, y);
+		} else if (BOUNDARY_FIXED_T == w->boundary[EDGE_RIGHT]) {
+			world_sim_th_fixed(w, r, y, w->boundary_thermo[EDGE_RIGHT]);
+		}
+
+		if (!wrap_h) {
+			continue;
+		}
+
+		if (MAT_NONE != w->dot[0][y]) {
+			world_sim_th_conduction(w, 0, y, r, y);
+		} else if (MAT_NONE != w->dot[r][y]) {
+			world_sim_th_conduction(w, r, y, 0, y);
+		}
+
+		if (y >= b) {
+			continue;
+		}
+
+		/* dots spread sideways only while falling, so diagonally,
+		 * which would make gases sink, so they stay on their side
+		 */
+		if (MAT_NONE != w->dot[0][y] &&
+		    MS_STATIC != w->state[0][y] &&
+		    MS_GAS != w->state[0][y] &&
+		    LOCK_FROZEN != w->lock[0][y] &&
+		    world_can_displace(w, 0, y, r, y + 1)) {
+			world_swap_dots(w, 0, y, r, y + 1);
+		} else if (MAT_NONE != w->dot[r][y] &&
+		           MS_STATIC != w->state[r][y] &&
+		           MS_GAS != w->state[r][y] &&
+		           LOCK_FROZEN != w->lock[r][y] &&
+		           world_can_displace(w, r, y, 0, y + 1)) {
+			world_swap_dots(w, r, y, 0, y + 1);
+		}
+	}
 }
 
 static void
@@ -428,6 +777,10 @@ world_sim_chemical_reaction(struct World *w,
 {
 	float th;
 
+	if (LOCK_FROZEN == w->lock[x][y] || LOCK_FROZEN == w->lock[dx][dy]) {
+		return;
+	}
+
 	w->dissol[x][y] += MAT_ACIDITY[w->dot[dx][dy]] *
 	                   MAT_ACID_VULN[w->dot[x][y]];
 	if (w->dissol[x][y] >= 1.0) {
@@ -467,6 +820,10 @@ world_sim_gravity(struct World *w,
                   const int     x,
                   const int     y)
 {
+	if (LOCK_FROZEN == w->lock[x][y]) {
+		return;
+	}
+
 	switch (w->state[x][y]) {
 	case MS_GAS:
 		world_drop_gas(w, x, y);
@@ -494,7 +851,9 @@ world_sim_th_conduction(struct World *w,
 {
 	float c1, c2, combCond;
 
-	if (MAT_NONE == w->dot[x2][y2]) {
+	if (MAT_NONE == w->dot[x2][y2] ||
+	    LOCK_FROZEN == w->lock[x][y] ||
+	    LOCK_FROZEN == w->lock[x2][y2]) {
 		return;
 	}
 
@@ -506,6 +865,21 @@ world_sim_th_conduction(struct World *w,
 	w->thermo[x2][y2] += c2;
 }
 
+/* Conducts heat with a wall of the fixed temperature t.
+ */
+static void
+world_sim_th_fixed(struct World *w,
+                   const int     x,
+                   const int     y,
+                   const float   t)
+{
+	if (MAT_NONE == w->dot[x][y] || LOCK_FROZEN == w->lock[x][y]) {
+		return;
+	}
+
+	w->thermo[x][y] += (t - w->thermo[x][y]) * MAT_TH_COND[w->dot[x][y]];
+}
+
 static void
 world_swap_dots(struct World *w,
                 const int     x,
@@ -533,21 +907,37 @@ world_swap_dots(struct World *w,
 }
 
 void
-world_update(struct World *w,
-             const float   spawner_temperature)
+world_update(struct World *w)
 {
 	int x, y;
 
 	for (x = 0; x < w->w; x++) {
 		for (y = 0; y < w->h; y++) {
-			if (w->spawner[x][y]) {
+			if (w->spawner[x][y] &&
+			    LOCK_FROZEN != w->lock[x][y] &&
+			    (w->spawner_interval[x][y] <= 1 ||
+			     w->tick % w->spawner_interval[x][y] == 0) &&
+			    (rand() % 100) < w->spawner_chance[x][y]) {
 				w->dot[x][y] = w->spawner_mat[x][y];
-				w->thermo[x][y] = spawner_temperature;
+				w->thermo[x][y] = w->spawner_thermo[x][y];
+
+				if (w->spawner_burst[x][y] > 0) {
+					w->spawner_burst[x][y]--;
+					if (0 == w->spawner_burst[x][y]) {
+						w->spawner[x][y] = false;
+					}
+				}
+			}
+
+			if (w->emitter[x][y] != EMITTER_NONE) {
+				world_update_emitter(w, x, y);
 			}
 
 			world_update_dot_from_thermo(w, x, y);
 		}
 	}
+
+	w->tick++;
 }
 
 static void
@@ -578,6 +968,66 @@ world_update_dot_from_thermo(struct World *w,
 	}
 }
 
+static void
+world_update_emitter(struct World *w,
+                     const int     x,
+                     const int     y)
+{
+	int       i;
+	int       nx, ny;
+	float     t = w->emitter_thermo[x][y];
+	const int dirs[5][2] = {{0, 0}, {-1, 0}, {1, 0}, {0, -1}, {0, 1}};
+
+	switch (w->emitter[x][y]) {
+	case EMITTER_DRAIN:
+		if (LOCK_FROZEN == w->lock[x][y]) {
+			break;
+		}
+
+		if (MAT_NONE == w->emitter_mat[x][y] ||
+		    w->emitter_mat[x][y] == w->dot[x][y]) {
+			world_clear_dot(w, x, y);
+		}
+		break;
+
+	case EMITTER_HEAT_SOURCE:
+	case EMITTER_HEAT_SINK:
+		for (i = 0; i < 5; i++) {
+			nx = x + dirs[i][0];
+			ny = y + dirs[i][1];
+
+			if (nx < 0 || nx >= w->w ||
+			    ny < 0 || ny >= w->h ||
+			    LOCK_FROZEN == w->lock[nx][ny]) {
+				continue;
+			}
+
+			if (EMITTER_HEAT_SOURCE == w->emitter[x][y]) {
+				if (!w->emitter_fixed[x][y]) {
+					w->thermo[nx][ny] += t;
+				} else if (w->thermo[nx][ny] < t) {
+					w->thermo[nx][ny] = t;
+				}
+			} else {
+				if (!w->emitter_fixed[x][y]) {
+					w->thermo[nx][ny] -= t;
+				} else if (w->thermo[nx][ny] > t) {
+					w->thermo[nx][ny] = t;
+				}
+
+				if (w->thermo[nx][ny] < 0.0) {
+					w->thermo[nx][ny] = 0.0;
+				}
+			}
+		}
+		break;
+
+	case EMITTER_NONE:
+	case EMITTER_COUNT:
+		break;
+	}
+}
+
 void
 world_use_brush(struct World   *w,
                 const enum Mat  m,
@@ -607,16 +1057,54 @@ world_use_brush(struct World   *w,
 
 	for (x = x1; x <= x2; x++) {
 		for (y = y1; y <= y2; y++) {
-			w->dissol[x][y] = 0.0;
-			w->dot[x][y] = m;
-			w->oxid[x][y] = 0.0;
-			w->thermo[x][y] = t;
+			world_set_dot(w, m, t, x, y);
+		}
+	}
+}
 
-			if (w->thermo[x][y] >= MAT_BOIL_P[w->dot[x][y]]) {
-				if (MAT_MELT_DECOMP[w->dot[x][y]]) {
-					w->dot[x][y] = mat_melt_prdct(w->dot[x][y]);
-				}
+void
+world_use_circle(struct World   *w,
+                 const enum Mat  m,
+                 const float     t,
+                 const int       x_c,
+                 const int       y_c,
+                 const int       radius,
+                 const bool      filled)
+{
+	int d2;
+	int inner = (radius - 1) * (radius - 1) + (radius - 1);
+	int outer = radius * radius + radius;
+	int x, y;
+	int x1 = x_c - radius;
+	int x2 = x_c + radius;
+	int y1 = y_c - radius;
+	int y2 = y_c + radius;
+
+	if (x1 < 0) {
+		x1 = 0;
+	}
+	if (x2 >= w->w) {
+		x2 = w->w - 1;
+	}
+	if (y1 < 0) {
+		y1 = 0;
+	}
+	if (y2 >= w->h) {
+		y2 = w->h - 1;
+	}
+
+	for (x = x1; x <= x2; x++) {
+		for (y = y1; y <= y2; y++) {
+			d2 = (x - x_c) * (x - x_c) + (y - y_c) * (y - y_c);
+
+			if (d2 > outer) {
+				continue;
+			}
+			if (!filled && radius > 0 && d2 <= inner) {
+				continue;
 			}
+
+			world_set_dot(w, m, t, x, y);
 		}
 	}
 }
@@ -648,12 +1136,275 @@ world_use_eraser(struct World *w,
 
 	for (x = x1; x <= x2; x++) {
 		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
 			world_clear_dot(w, x, y);
+			w->emitter[x][y] = EMITTER_NONE;
 			w->spawner[x][y] = 0;
 		}
 	}
 }
 
+void
+world_use_fill(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x,
+               const int       y)
+{
+	int       cx, cy;
+	int       i;
+	int       len = 0;
+	int       nx, ny;
+	int      *stack;
+	bool     *seen;
+	enum Mat  target;
+	const int dirs[4][2] = {{-1, 0}, {1, 0}, {0, -1}, {0, 1}};
+
+	if (x < 0 || x >= w->w ||
+	    y < 0 || y >= w->h) {
+		return;
+	}
+
+	target = w->dot[x][y];
+	if (target == m || LOCK_NONE != w->lock[x][y]) {
+		return;
+	}
+
+	/* Each dot is pushed at most once, as remembered by seen.
+	 * Whether it still matches target can't tell,
+	 * because world_set_dot may turn m back into target,
+	 * for example when it melts at t.
+	 */
+	stack = malloc(sizeof(int) * 2 * w->w * w->h);
+	seen = calloc(w->w * w->h, sizeof(bool));
+	if (NULL == stack || NULL == seen) {
+		free(stack);
+		free(seen);
+		return;
+	}
+
+	world_set_dot(w, m, t, x, y);
+	seen[x * w->h + y] = true;
+	stack[len++] = x;
+	stack[len++] = y;
+
+	while (len > 0) {
+		cy = stack[--len];
+		cx = stack[--len];
+
+		for (i = 0; i < 4; i++) {
+			nx = cx + dirs[i][0];
+			ny = cy + dirs[i][1];
+
+			if (nx < 0 || nx >= w->w ||
+			    ny < 0 || ny >= w->h ||
+			    seen[nx * w->h + ny] ||
+			    w->dot[nx][ny] != target ||
+			    LOCK_NONE != w->lock[nx][ny]) {
+				continue;
+			}
+
+			world_set_dot(w, m, t, nx, ny);
+			seen[nx * w->h + ny] = true;
+			stack[len++] = nx;
+			stack[len++] = ny;
+		}
+	}
+
+	free(seen);
+	free(stack);
+}
+
+void
+world_use_hammer(struct World *w,
+                 const int     x_c,
+                 const int     y_c,
+                 const int     radius)
+{
+	int x, y;
+	int x1 = x_c - radius;
+	int x2 = x_c + radius;
+	int y1 = y_c - radius;
+	int y2 = y_c + radius;
+
+	if (x1 < 0) {
+		x1 = 0;
+	}
+	if (x2 >= w->w) {
+		x2 = w->w - 1;
+	}
+	if (y1 < 0) {
+		y1 = 0;
+	}
+	if (y2 >= w->h) {
+		y2 = w->h - 1;
+	}
+
+	for (x = x1; x <= x2; x++) {
+		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y] ||
+			    MS_STATIC != w->state[x][y] ||
+			    MAT_NONE == MAT_CRUSH_PRDCT[w->dot[x][y]]) {
+				continue;
+			}
+
+			w->dot[x][y] = MAT_CRUSH_PRDCT[w->dot[x][y]];
+			world_update_dot_from_thermo(w, x, y);
+		}
+	}
+}
+
+void
+world_use_igniter(struct World *w,
+                  const int     x_c,
+                  const int     y_c,
+                  const int     radius)
+{
+	int   x, y;
+	int   x1 = x_c - radius;
+	int   x2 = x_c + radius;
+	int   y1 = y_c - radius;
+	int   y2 = y_c + radius;
+	float ign_t;
+
+	if (x1 < 0) {
+		x1 = 0;
+	}
+	if (x2 >= w->w) {
+		x2 = w->w - 1;
+	}
+	if (y1 < 0) {
+		y1 = 0;
+	}
+	if (y2 >= w->h) {
+		y2 = w->h - 1;
+	}
+
+	for (x = x1; x <= x2; x++) {
+		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y] ||
+			    0 == MAT_IGN_P[w->dot[x][y]]) {
+				continue;
+			}
+
+			ign_t = MAT_IGN_P[w->dot[x][y]] + IGNITER_EXCESS;
+			if (w->thermo[x][y] < ign_t) {
+				w->thermo[x][y] = ign_t;
+			}
+		}
+	}
+}
+
+void
+world_use_lock(struct World    *w,
+               const enum Lock  l,
+               const int        x_c,
+               const int        y_c,
+               const int        radius)
+{
+	int x, y;
+	int x1 = x_c - radius;
+	int x2 = x_c + radius;
+	int y1 = y_c - radius;
+	int y2 = y_c + radius;
+
+	if (x1 < 0) {
+		x1 = 0;
+	}
+	if (x2 >= w->w) {
+		x2 = w->w - 1;
+	}
+	if (y1 < 0) {
+		y1 = 0;
+	}
+	if (y2 >= w->h) {
+		y2 = w->h - 1;
+	}
+
+	for (x = x1; x <= x2; x++) {
+		for (y = y1; y <= y2; y++) {
+			w->lock[x][y] = l;
+		}
+	}
+}
+
+void
+world_use_line(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x1,
+               const int       y1,
+               const int       x2,
+               const int       y2,
+               const int       radius)
+{
+	int dx = abs(x2 - x1);
+	int dy = -abs(y2 - y1);
+	int e2;
+	int err = dx + dy;
+	int sx = x1 < x2 ? 1 : -1;
+	int sy = y1 < y2 ? 1 : -1;
+	int x = x1;
+	int y = y1;
+
+	while (1) {
+		world_use_brush(w, m, t, x, y, radius);
+
+		if (x == x2 && y == y2) {
+			break;
+		}
+
+		e2 = 2 * err;
+		if (e2 >= dy) {
+			err += dy;
+			x += sx;
+		}
+		if (e2 <= dx) {
+			err += dx;
+			y += sy;
+		}
+	}
+}
+
+void
+world_use_rect(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x1,
+               const int       y1,
+               const int       x2,
+               const int       y2,
+               const bool      filled)
+{
+	int x, y;
+	int l = x1 < x2 ? x1 : x2;
+	int r = x1 < x2 ? x2 : x1;
+	int u = y1 < y2 ? y1 : y2;
+	int d = y1 < y2 ? y2 : y1;
+
+	for (x = l; x <= r; x++) {
+		if (x < 0 || x >= w->w) {
+			continue;
+		}
+
+		for (y = u; y <= d; y++) {
+			if (y < 0 || y >= w->h) {
+				continue;
+			}
+			if (!filled &&
+			    x != l && x != r &&
+			    y != u && y != d) {
+				continue;
+			}
+
+			world_set_dot(w, m, t, x, y);
+		}
+	}
+}
+
 void
 world_use_cooler(struct World *w,
                  const float   delta,
@@ -682,6 +1433,10 @@ world_use_cooler(struct World *w,
 
 	for (x = x1; x <= x2; x++) {
 		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
 			w->thermo[x][y] -= delta;
 
 			if (w->thermo[x][y] < 0.0) {
@@ -719,11 +1474,52 @@ world_use_heater(struct World *w,
 
 	for (x = x1; x <= x2; x++) {
 		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
 			w->thermo[x][y] += delta;
 		}
 	}
 }
 
+void
+world_use_thermostat(struct World *w,
+                     const float   t,
+                     const int     x_c,
+                     const int     y_c,
+                     const int     radius)
+{
+	int x, y;
+	int x1 = x_c - radius;
+	int x2 = x_c + radius;
+	int y1 = y_c - radius;
+	int y2 = y_c + radius;
+
+	if (x1 < 0) {
+		x1 = 0;
+	}
+	if (x2 >= w->w) {
+		x2 = w->w - 1;
+	}
+	if (y1 < 0) {
+		y1 = 0;
+	}
+	if (y2 >= w->h) {
+		y2 = w->h - 1;
+	}
+
+	for (x = x1; x <= x2; x++) {
+		for (y = y1; y <= y2; y++) {
+			if (LOCK_NONE != w->lock[x][y]) {
+				continue;
+			}
+
+			w->thermo[x][y] = t;
+		}
+	}
+}
+
 void
 world_free(struct World *w)
 {
@@ -747,6 +1543,56 @@ world_free(struct World *w)
 		w->_dot = NULL;
 	}
 
+	if (w->emitter != NULL) {
+		free(w->emitter);
+		w->emitter = NULL;
+	}
+
+	if (w->_emitter != NULL) {
+		free(w->_emitter);
+		w->_emitter = NULL;
+	}
+
+	if (w->emitter_fixed != NULL) {
+		free(w->emitter_fixed);
+		w->emitter_fixed = NULL;
+	}
+
+	if (w->_emitter_fixed != NULL) {
+		free(w->_emitter_fixed);
+		w->_emitter_fixed = NULL;
+	}
+
+	if (w->emitter_mat != NULL) {
+		free(w->emitter_mat);
+		w->emitter_mat = NULL;
+	}
+
+	if (w->_emitter_mat != NULL) {
+		free(w->_emitter_mat);
+		w->_emitter_mat = NULL;
+	}
+
+	if (w->emitter_thermo != NULL) {
+		free(w->emitter_thermo);
+		w->emitter_thermo = NULL;
+	}
+
+	if (w->_emitter_thermo != NULL) {
+		free(w->_emitter_thermo);
+		w->_emitter_thermo = NULL;
+	}
+
+	if (w->lock != NULL) {
+		free(w->lock);
+		w->lock = NULL;
+	}
+
+	if (w->_lock != NULL) {
+		free(w->_lock);
+		w->_lock = NULL;
+	}
+
 	if (w->oxid != NULL) {
 		free(w->oxid);
 		w->oxid = NULL;
@@ -767,6 +1613,36 @@ world_free(struct World *w)
 		w->_spawner = NULL;
 	}
 
+	if (w->spawner_burst != NULL) {
+		free(w->spawner_burst);
+		w->spawner_burst = NULL;
+	}
+
+	if (w->_spawner_burst != NULL) {
+		free(w->_spawner_burst);
+		w->_spawner_burst = NULL;
+	}
+
+	if (w->spawner_chance != NULL) {
+		free(w->spawner_chance);
+		w->spawner_chance = NULL;
+	}
+
+	if (w->_spawner_chance != NULL) {
+		free(w->_spawner_chance);
+		w->_spawner_chance = NULL;
+	}
+
+	if (w->spawner_interval != NULL) {
+		free(w->spawner_interval);
+		w->spawner_interval = NULL;
+	}
+
+	if (w->_spawner_interval != NULL) {
+		free(w->_spawner_interval);
+		w->_spawner_interval = NULL;
+	}
+
 	if (w->spawner_mat != NULL) {
 		free(w->spawner_mat);
 		w->spawner_mat = NULL;
@@ -777,6 +1653,16 @@ world_free(struct World *w)
 		w->_spawner_mat = NULL;
 	}
 
+	if (w->spawner_thermo != NULL) {
+		free(w->spawner_thermo);
+		w->spawner_thermo = NULL;
+	}
+
+	if (w->_spawner_thermo != NULL) {
+		free(w->_spawner_thermo);
+		w->_spawner_thermo = NULL;
+	}
+
 	if (w->state != NULL) {
 		free(w->state);
 		w->state = NULL;
@@ -9,14 +9,95 @@
 
 #include "hawps_mat.h"
 
+/* What happens to dots at an edge of the world.
+ * A wall keeps them inside, while void deletes dots leaving the world.
+ * Dots only leave through the top as gas, since only gases rise.
+ * Wrap brings dots leaving the world back in at the opposite edge,
+ * and is in effect for both opposite edges, if either of them wraps.
+ * A fixed temperature wall is a wall, that conducts heat
+ * as if it was always at boundary_thermo.
+ */
+enum Boundary {
+	BOUNDARY_WALL,
+	BOUNDARY_VOID,
+	BOUNDARY_WRAP,
+	BOUNDARY_FIXED_T,
+
+	BOUNDARY_COUNT
+};
+
+enum Edge {
+	EDGE_TOP,
+	EDGE_BOTTOM,
+	EDGE_LEFT,
+	EDGE_RIGHT,
+
+	EDGE_COUNT
+};
+
+/* Locked dots are left alone by every tool, except for the one locking them.
+ * Frozen dots are also left alone by the simulation,
+ * so they don't move, react or conduct heat, and nothing moves into them.
+ * A lock belongs to the place, not to the dot that is there.
+ */
+enum Lock {
+	LOCK_NONE,
+	LOCK_TOOLS,
+	LOCK_FROZEN,
+
+	LOCK_COUNT
+};
+
+/* Emitters stay where they are placed and act on dots each world_update.
+ * A drain clears dots of its mat, or any dot if its mat is MAT_NONE.
+ * A heat source raises the temperature of itself and its 4 neighbors,
+ * and a heat sink lowers it.
+ * With fixed, they move the temperature to their own if it is lower or
+ * higher respectively, otherwise they add or remove their temperature as delta.
+ */
+enum Emitter {
+	EMITTER_NONE,
+	EMITTER_DRAIN,
+	EMITTER_HEAT_SOURCE,
+	EMITTER_HEAT_SINK,
+
+	EMITTER_COUNT
+};
+
 struct World {
 	int w;
 	int h;
 
+	/* counts world_update calls, for spawner intervals */
+	unsigned long tick;
+
+	enum Boundary boundary[EDGE_COUNT];
+	float         boundary_thermo[EDGE_COUNT];
+
+	enum Emitter  *_emitter;
+	enum Emitter **emitter;
+	bool          *_emitter_fixed;
+	bool         **emitter_fixed;
+	enum Mat      *_emitter_mat;
+	enum Mat     **emitter_mat;
+	float         *_emitter_thermo;
+	float        **emitter_thermo;
+
+	enum Lock  *_lock;
+	enum Lock **lock;
+
 	bool      *_spawner;
 	bool     **spawner;
+	int       *_spawner_burst;
+	int      **spawner_burst;
+	int       *_spawner_chance;
+	int      **spawner_chance;
+	int       *_spawner_interval;
+	int      **spawner_interval;
 	enum Mat  *_spawner_mat;
 	enum Mat **spawner_mat;
+	float     *_spawner_thermo;
+	float    **spawner_thermo;
 
 	float          *_dissol;
 	float         **dissol;
@@ -44,16 +125,82 @@ world_can_displace(struct World *w,
                    const int     dx,
                    const int     dy);
 
+/* Clears every dot, spawner and emitter.
+ * Like the tools, this leaves locked dots alone, and so do the other
+ * world_clear_* functions.
+ */
+void
+world_clear(struct World *w);
+
 void
 world_clear_dot(struct World *w,
                 const int     x,
                 const int     y);
 
+/* Clears every dot, but keeps spawners and emitters.
+ */
+void
+world_clear_dots(struct World *w);
+
+/* Clears every dot of the mat m.
+ */
+void
+world_clear_mat(struct World   *w,
+                const enum Mat  m);
+
+void
+world_clear_spawners(struct World *w);
+
+/* Sets the oxidation and dissolution of every dot back to 0.
+ */
+void
+world_reset_progress(struct World *w);
+
+/* Sets the boundary of an edge, see enum Boundary.
+ * The temperature t is only used by BOUNDARY_FIXED_T.
+ */
+void
+world_set_boundary(struct World        *w,
+                   const enum Edge      e,
+                   const enum Boundary  b,
+                   const float          t);
+
+/* Places an emitter, see enum Emitter.
+ * EMITTER_NONE removes it again.
+ */
+void
+world_set_emitter(struct World       *w,
+                  const enum Emitter  e,
+                  const enum Mat      m,
+                  const float         t,
+                  const bool          fixed,
+                  const int           x,
+                  const int           y);
+
+/* Places a spawner, which sets its dot to m of temperature t.
+ * It does so every interval ticks, each time with a chance of chance percent.
+ * If burst is above 0, the spawner removes itself after that many spawns.
+ */
+void
+world_set_spawner(struct World   *w,
+                  const enum Mat  m,
+                  const float     t,
+                  const int       x,
+                  const int       y,
+                  const int       interval,
+                  const int       chance,
+                  const int       burst);
+
+/* Sets the temperature of every dot that isn't locked.
+ */
+void
+world_set_thermo(struct World *w,
+                 const float   t);
+
 /* You may want to call world_sim after this.
  */
 void
-world_update(struct World *w,
-             const float   spawner_temperature);
+world_update(struct World *w);
 
 void
 world_use_brush(struct World   *w,
@@ -63,12 +210,86 @@ world_use_brush(struct World   *w,
                 const int       y_c,
                 const int       radius);
 
+/* Draws a circle of the given radius around the center.
+ * Without filled, only the outermost ring of dots is drawn.
+ */
+void
+world_use_circle(struct World   *w,
+                 const enum Mat  m,
+                 const float     t,
+                 const int       x_c,
+                 const int       y_c,
+                 const int       radius,
+                 const bool      filled);
+
 void
 world_use_eraser(struct World *w,
                  const int     x_c,
                  const int     y_c,
                  const int     radius);
 
+/* Replaces the contiguous area of the same mat as the dot at x, y.
+ * Dots only count as contiguous if they touch by side, not corner.
+ */
+void
+world_use_fill(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x,
+               const int       y);
+
+/* Stamps a brush of the given radius along the line.
+ */
+void
+world_use_line(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x1,
+               const int       y1,
+               const int       x2,
+               const int       y2,
+               const int       radius);
+
+/* Crushes static dots into their MAT_CRUSH_PRDCT, which can then fall.
+ * Dots without a crushed product are left alone.
+ */
+void
+world_use_hammer(struct World *w,
+                 const int     x_c,
+                 const int     y_c,
+                 const int     radius);
+
+/* Heats dots that can ignite to just above their MAT_IGN_P.
+ * Other dots, and those already hotter than that, are left alone.
+ */
+void
+world_use_igniter(struct World *w,
+                  const int     x_c,
+                  const int     y_c,
+                  const int     radius);
+
+/* Sets the lock of every dot in the radius, see enum Lock.
+ */
+void
+world_use_lock(struct World    *w,
+               const enum Lock  l,
+               const int        x_c,
+               const int        y_c,
+               const int        radius);
+
+/* The given corners can be in any order.
+ * Without filled, only the edges are drawn.
+ */
+void
+world_use_rect(struct World   *w,
+               const enum Mat  m,
+               const float     t,
+               const int       x1,
+               const int       y1,
+               const int       x2,
+               const int       y2,
+               const bool      filled);
+
 /* Using this to increase temperature, by giving a negative delta,
  * is inefficient. Cooling requires an additional check.
  * To heat, see world_use_heater
@@ -91,6 +312,15 @@ world_use_heater(struct World *w,
                  const int     y_c,
                  const int     radius);
 
+/* Sets the temperature of every dot in the radius to t.
+ */
+void
+world_use_thermostat(struct World *w,
+                     const float   t,
+                     const int     x_c,
+                     const int     y_c,
+                     const int     radius);
+
 /* You SHOULD call world_update before this.
  */
 void
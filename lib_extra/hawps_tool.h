@@ -11,10 +11,25 @@ enum Tool {
 	TOOL_ERASER,
 	TOOL_HEATER,
 	TOOL_COOLER,
+	TOOL_LINE,
+	TOOL_RECTANGLE,
+	TOOL_CIRCLE,
+	TOOL_FILL,
+	TOOL_SELECT,
+	TOOL_PREFAB,
+	TOOL_DRAIN,
+	TOOL_HEAT_SOURCE,
+	TOOL_HEAT_SINK,
+	TOOL_HAMMER,
+	TOOL_IGNITER,
+	TOOL_EYEDROPPER,
+	TOOL_MIXTURE,
+	TOOL_LOCK,
+	TOOL_TEXT,
 
 	TOOL_COUNT
 };
 
-static const char *TOOL_NAME[] = {"Brush", "Spawner", "Eraser", "Heater", "Cooler"};
+static const char *TOOL_NAME[] = {"Brush", "Spawner", "Eraser", "Heater", "Cooler", "Line", "Rectangle", "Circle", "Fill", "Select", "Prefab", "Drain", "HeatSource", "HeatSink", "Hammer", "Igniter", "Eyedropper", "Mixture", "Lock", "Text"};
 
 #endif /* _HAWPS_TOOL_H */